@@ -0,0 +1,100 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package helper contains helper functions for the OpenStack provider APIs.
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+// CloudProfileConfigFromCluster decodes the provider specific cloud profile configuration for a cluster.
+func CloudProfileConfigFromCluster(cluster *controller.Cluster) (*api.CloudProfileConfig, error) {
+	cloudProfileConfig := &api.CloudProfileConfig{}
+	if cluster.CloudProfile != nil && cluster.CloudProfile.Spec.ProviderConfig != nil && cluster.CloudProfile.Spec.ProviderConfig.Raw != nil {
+		if err := json.Unmarshal(cluster.CloudProfile.Spec.ProviderConfig.Raw, cloudProfileConfig); err != nil {
+			return nil, err
+		}
+	}
+	return cloudProfileConfig, nil
+}
+
+// FindKeyStoneURL finds the Keystone URL for the given region, falling back to the global default.
+func FindKeyStoneURL(keyStoneURLs []api.KeyStoneURL, globalURL string, region string) (string, error) {
+	for _, keyStoneURL := range keyStoneURLs {
+		if keyStoneURL.Region == region {
+			return keyStoneURL.URL, nil
+		}
+	}
+	if len(globalURL) > 0 {
+		return globalURL, nil
+	}
+	return "", fmt.Errorf("cannot find keystone URL for region %q", region)
+}
+
+// FindKeyStoneCACert finds the region-specific Keystone CA certificate, falling back to the global default.
+func FindKeyStoneCACert(keyStoneURLs []api.KeyStoneURL, globalCACert *string, region string) *string {
+	for _, keyStoneURL := range keyStoneURLs {
+		if keyStoneURL.Region == region && keyStoneURL.CACert != nil {
+			return keyStoneURL.CACert
+		}
+	}
+	return globalCACert
+}
+
+// FindFloatingPool finds the floating pool for the given name and region. If no name is given, the first
+// non-constraining floating pool, or the only configured one, is returned. If tags are given, candidate pools are
+// additionally filtered by whether they carry all of the requested tags.
+func FindFloatingPool(floatingPools []api.FloatingPool, name string, region string, tags []string) (*api.FloatingPool, error) {
+	var candidates []api.FloatingPool
+
+	for _, pool := range floatingPools {
+		if pool.Region != nil && *pool.Region != region {
+			continue
+		}
+		if len(name) > 0 && pool.Name != name {
+			continue
+		}
+		if !hasAllTags(pool.Tags, tags) {
+			continue
+		}
+		candidates = append(candidates, pool)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no floating pool found for name %q and region %q", name, region)
+	}
+	return &candidates[0], nil
+}
+
+func hasAllTags(poolTags, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	have := map[string]bool{}
+	for _, t := range poolTags {
+		have[t] = true
+	}
+	for _, t := range required {
+		if !have[t] {
+			return false
+		}
+	}
+	return true
+}
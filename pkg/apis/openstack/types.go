@@ -0,0 +1,301 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openstack contains the internal, hub representation of the OpenStack provider
+// specific API types used by the extension controllers.
+package openstack
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InfrastructureConfig infrastructure configuration resource.
+type InfrastructureConfig struct {
+	metav1.TypeMeta
+	// FloatingPoolName contains the name of the floating pool to be used.
+	FloatingPoolName string
+	// FloatingPoolSubnetName contains the name or id of the floating pool subnet to be used.
+	FloatingPoolSubnetName *string
+	// Networks is the OpenStack specific network configuration.
+	Networks Networks
+}
+
+// Networks holds information about the Kubernetes and infrastructure networks.
+type Networks struct {
+	// ID is the ID of an existing private network.
+	ID *string
+	// Router indicates whether to use an existing router.
+	Router *Router
+	// Workers is a CIDRs of a worker subnet (private) to create.
+	Workers string
+	// ShareNetwork holds the configuration for the Manila share network.
+	ShareNetwork *ShareNetwork
+	// ShareNetworks declares additional, named Manila share networks for the infrastructure controller to
+	// reconcile against Neutron, superseding ShareNetwork for setups that need more than one (e.g. to satisfy
+	// per-availability-zone AZAffinity declared in ControlPlaneConfig.CSI.Manila.ShareNetworks).
+	ShareNetworks []NamedShareNetwork
+	// Zones holds per-availability-zone worker network configuration. When set, it supersedes Workers.
+	Zones []ZoneNetwork
+	// InternalLoadBalancer configures a fully-private shoot: the API server is fronted by an internal VIP
+	// inside the worker subnet instead of a router attached to an external floating network, and a jump host
+	// is provisioned to allow operators to reach the otherwise air-gapped landing zone.
+	InternalLoadBalancer *InternalLoadBalancer
+}
+
+// InternalLoadBalancer configures a fully-private shoot.
+type InternalLoadBalancer struct {
+	// Enabled switches the shoot's API server endpoint from an externally reachable floating IP to an
+	// internal VIP inside the worker subnet.
+	Enabled bool
+	// JumpHost configures the bastion VM used to reach the internal landing zone.
+	JumpHost *JumpHost
+}
+
+// JumpHost configures the bastion VM created (or referenced) to reach a fully-private shoot.
+type JumpHost struct {
+	// ImageName is the name of the Glance image used for the jump host VM.
+	ImageName string
+	// FlavorName is the name of the Nova flavor used for the jump host VM.
+	FlavorName string
+	// ID references a pre-existing jump host instance instead of creating a new one.
+	ID *string
+}
+
+// ZoneNetwork holds the network configuration for a single availability zone.
+type ZoneNetwork struct {
+	// Name is the name of the availability zone.
+	Name string
+	// WorkerCIDR is the CIDR of the worker subnet to create in this zone.
+	WorkerCIDR string
+	// RouterInterface indicates whether this zone's subnet should be attached via its own
+	// router interface instead of sharing the infrastructure's default router.
+	RouterInterface *bool
+}
+
+// Router indicates whether to use an existing router and its id.
+type Router struct {
+	// ID is the router id.
+	ID string
+	// ExternalFixedIPs are pre-allocated IPs to assign to the router's external gateway port.
+	ExternalFixedIPs []string
+	// ReserveFloatingIP indicates that a floating IP should be allocated and reserved for the
+	// router's external gateway, independent of the router's lifecycle.
+	ReserveFloatingIP *bool
+	// FloatingIPID references a pre-existing, operator-provided floating IP to bind to the
+	// router's external gateway (bring-your-own IP).
+	FloatingIPID *string
+}
+
+// ShareNetwork holds the configuration for the Manila share network.
+type ShareNetwork struct {
+	// Enabled indicates if the share network should be created.
+	Enabled bool
+}
+
+// NamedShareNetwork declares a single, named Manila share network to be created by the infrastructure
+// controller, referenced by name from ControlPlaneConfig.CSI.Manila.ShareNetworks.
+type NamedShareNetwork struct {
+	// Name is the name of the share network.
+	Name string
+	// Zone restricts the share network's subnet to a specific availability zone. When empty, the
+	// infrastructure's primary/default subnet is used.
+	Zone *string
+}
+
+// InfrastructureStatus contains information about created infrastructure resources.
+type InfrastructureStatus struct {
+	metav1.TypeMeta
+	// Networks contains information about the created networks and their components.
+	Networks NetworkStatus
+	// SecurityGroups is a list of security groups that have been created.
+	SecurityGroups []SecurityGroup
+	// Node contains information about Node related resources.
+	Node NodeStatus
+}
+
+// NetworkStatus contains information about a generated Network, Subnet and related resources.
+type NetworkStatus struct {
+	// ID is the Network id.
+	ID string
+	// Name is the Network name.
+	Name string
+	// FloatingPool contains the FloatingPoolStatus
+	FloatingPool FloatingPoolStatus
+	// Router contains information about the Router, e.g. ID.
+	Router RouterStatus
+	// Subnets is a list of subnets that have been created.
+	Subnets []Subnet
+	// ShareNetwork holds the status of the Manila share network, when enabled. Superseded by
+	// ShareNetworks for setups with more than one declared share network.
+	ShareNetwork *ShareNetworkStatus
+	// ShareNetworks contains the status of the Manila share networks reconciled for this shoot.
+	ShareNetworks []ShareNetworkStatus
+	// InternalEndpoint is the internal VIP fronting the API server of a fully-private shoot.
+	InternalEndpoint string
+	// JumpHost is the bastion used to reach a fully-private shoot, when InternalLoadBalancer is enabled.
+	JumpHost *JumpHostStatus
+}
+
+// JumpHostStatus contains information about the bastion VM of a fully-private shoot.
+type JumpHostStatus struct {
+	// IP is the jump host's externally reachable floating IP.
+	IP string
+	// SSHKeyName is the name of the SSH key accepted by the jump host.
+	SSHKeyName string
+}
+
+// Subnet holds information about a subnet.
+type Subnet struct {
+	// Purpose is a logical description of the subnet.
+	Purpose Purpose
+	// ID is the subnet id.
+	ID string
+	// Zone is the availability zone this subnet was created in. Empty for the legacy,
+	// single-subnet layout.
+	Zone string
+}
+
+// Purpose is a string alias.
+type Purpose string
+
+const (
+	// PurposeNodes is the purpose for the worker/node subnet.
+	PurposeNodes Purpose = "nodes"
+)
+
+// FloatingPoolStatus contains information about the floating pool.
+type FloatingPoolStatus struct {
+	// ID is the floating pool id.
+	ID string
+	// Name is the floating pool name.
+	Name string
+}
+
+// RouterStatus contains information about the Router and related resources.
+type RouterStatus struct {
+	// ID is the Router id.
+	ID string
+	// IP is the router's internal gateway IP.
+	IP string
+	// PublicIP is the stable, externally reachable IP assigned to the router's external
+	// gateway. It is tracked separately from IP so that it can be reserved and kept stable
+	// across router recreations.
+	PublicIP string
+}
+
+// SecurityGroup is a NetworkStatus's security group configuration.
+type SecurityGroup struct {
+	// Purpose is a logical description of the security group.
+	Purpose Purpose
+	// ID is the security group id.
+	ID string
+	// Name is the security group name.
+	Name string
+}
+
+// NodeStatus contains information about Node related resources.
+type NodeStatus struct {
+	// KeyName is the name of the SSH key.
+	KeyName string
+}
+
+// ShareNetworkStatus contains the status of the Manila share network.
+type ShareNetworkStatus struct {
+	// Name is the name of the share network.
+	Name string
+	// ID is the id of the share network.
+	ID string
+}
+
+// CloudProfileConfig contains provider-specific configuration that is embedded into Gardener's `CloudProfile`
+// resource.
+type CloudProfileConfig struct {
+	metav1.TypeMeta
+	// KeyStoneURL is the URL for the OpenStack Keystone service. It is used for authentication and authorization.
+	KeyStoneURL string
+	// KeyStoneURLs is a region-specific list of Keystone URLs.
+	KeyStoneURLs []KeyStoneURL
+	// KeyStoneForceInsecure is a flag to control whether the certificate verification should be skipped.
+	KeyStoneForceInsecure bool
+	// KeyStoneCACert is the CA bundle used to verify the Keystone server's certificate, shared across regions.
+	KeyStoneCACert *string
+	// DHCPDomain is the dhcp domain of the OpenStack system.
+	DHCPDomain *string
+	// RequestTimeout specifies the HTTP request timeout against the OpenStack API.
+	RequestTimeout *metav1.Duration
+	// UseOctavia indicates whether the OpenStack Octavia network load balancing is used.
+	UseOctavia *bool
+	// UseSNAT indicates whether S-NAT is supposed to be used for the Router.
+	UseSNAT *bool
+	// DNSServers is a list of IPs of DNS servers used while creating subnets.
+	DNSServers []string
+	// RescanBlockStorageOnResize specifies whether the rescan-on-resize behaviour is enabled for Cinder volumes.
+	RescanBlockStorageOnResize *bool
+	// IgnoreVolumeAZ specifies whether the volume availability zone should be ignored when scheduling.
+	IgnoreVolumeAZ *bool
+	// NodeVolumeAttachLimit specifies the number of attachable volumes per node.
+	NodeVolumeAttachLimit *int32
+	// Constraints is an object containing constraints for certain values in the control plane config.
+	Constraints Constraints
+	// WorkloadIdentity contains the default settings for authenticating to Keystone via a projected Kubernetes
+	// service account token instead of long-lived credentials.
+	WorkloadIdentity *WorkloadIdentity
+}
+
+// WorkloadIdentity contains the default settings for Keystone federation via a projected service account token.
+type WorkloadIdentity struct {
+	// TokenAudience is the default audience requested for the projected service account token, used unless a
+	// Shoot-specific audience is given in the cloudprovider secret.
+	TokenAudience string
+	// TokenFilePath is the default path at which the projected service account token is mounted into the
+	// cloud-controller-manager, CSI and machine-controller-manager pods, used unless a Shoot-specific path is
+	// given in the cloudprovider secret.
+	TokenFilePath string
+}
+
+// KeyStoneURL is a region specific Keystone URL.
+type KeyStoneURL struct {
+	// Region is the name of the region.
+	Region string
+	// URL is the keystone URL for this region.
+	URL string
+	// CACert is the region-specific CA bundle used to verify the Keystone server's certificate, taking
+	// precedence over CloudProfileConfig.KeyStoneCACert for this region.
+	CACert *string
+}
+
+// Constraints is an object containing constraints for the shoots.
+type Constraints struct {
+	// FloatingPools contains constraints regarding allowed values for the 'floatingPoolName' block in the control
+	// plane config.
+	FloatingPools []FloatingPool
+}
+
+// FloatingPool contains constraints regarding allowed values of the 'floatingPoolName' block in the control plane
+// config.
+type FloatingPool struct {
+	// Name is the name of the floating pool.
+	Name string
+	// Region is the region name.
+	Region *string
+	// DefaultFloatingSubnet is the default floating subnet for this floating pool.
+	DefaultFloatingSubnet *string
+	// NonConstraining if set to true, indicates that this floating pool is not constraining, i.e. that other
+	// floating pools can also be specified in the shoot.
+	NonConstraining *bool
+	// LoadBalancerClasses contains a list of supported labeled load balancer classes.
+	LoadBalancerClasses []LoadBalancerClass
+	// Tags allows matching a floating pool by OpenStack resource tags instead of an exact name.
+	Tags []string
+}
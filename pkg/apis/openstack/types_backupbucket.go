@@ -0,0 +1,209 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupBucketConfig contains configuration for a BackupBucket.
+type BackupBucketConfig struct {
+	metav1.TypeMeta
+	// Provider contains the OpenStack-specific location of the backup bucket.
+	Provider BackupBucketProvider
+	// RetentionPolicy configures Swift object-lock (WORM) immutability for objects written to the backup
+	// bucket. When nil, the bucket is created without retention/legal-hold enabled.
+	RetentionPolicy *BackupBucketRetentionPolicy
+	// Lifecycle declares per-prefix Swift expiration rules for objects in the backup bucket.
+	Lifecycle *BackupBucketLifecycle
+	// Replication configures cross-region Swift container sync for the backup bucket.
+	Replication *BackupBucketReplication
+}
+
+// BackupBucketLifecycle declares per-prefix Swift expiration rules for objects in a BackupBucket.
+type BackupBucketLifecycle struct {
+	// Rules are the individual lifecycle rules, one per object key prefix.
+	Rules []BackupBucketLifecycleRule
+}
+
+// BackupBucketLifecycleRule declares the expiration behavior for objects matching a given key prefix.
+type BackupBucketLifecycleRule struct {
+	// Prefix is the object key prefix this rule applies to. An empty prefix matches all objects.
+	Prefix string
+	// DeleteAfter is the age at which matching objects are deleted. When nil, matching objects are never
+	// deleted by this rule.
+	DeleteAfter *metav1.Duration
+	// TransitionToColdAfter is the age at which matching objects are moved to cold storage. When nil,
+	// matching objects are never transitioned by this rule.
+	TransitionToColdAfter *metav1.Duration
+}
+
+// BackupBucketReplication configures cross-region Swift container sync for a BackupBucket.
+type BackupBucketReplication struct {
+	// Targets lists the peer regions this backup bucket is replicated to.
+	Targets []BackupBucketReplicationTarget
+}
+
+// BackupBucketReplicationTarget is a single cross-region Swift container-sync peer.
+type BackupBucketReplicationTarget struct {
+	// Region is the region of the companion container this bucket is synced to.
+	Region string
+	// SecretRef references the secret holding the credentials used to authenticate against the target
+	// region's Swift endpoint.
+	SecretRef corev1.SecretReference
+}
+
+// BackupBucketProvider contains the OpenStack-specific location of a BackupBucket.
+type BackupBucketProvider struct {
+	// Region is the region in which the backup bucket is located.
+	Region string
+	// AvailabilityZone restricts the bucket to a single Swift availability zone. When empty, the bucket is
+	// created without a zone restriction.
+	AvailabilityZone *string
+	// Backend selects the object-store backend the bucket is created against. When empty, Swift is assumed.
+	// This field is immutable once the BackupBucket has been created.
+	Backend BackupBucketBackend
+	// Swift contains backend-specific configuration for the Swift backend. Only evaluated when Backend is
+	// BackupBucketBackendSwift.
+	Swift *BackupBucketSwift
+	// S3 contains backend-specific configuration for the S3-compatible (e.g. Ceph RGW) backend. Only
+	// evaluated when Backend is BackupBucketBackendS3.
+	S3 *BackupBucketS3
+}
+
+// BackupBucketBackend is the object-store backend a BackupBucket is created against.
+type BackupBucketBackend string
+
+const (
+	// BackupBucketBackendSwift selects the OpenStack Swift backend.
+	BackupBucketBackendSwift BackupBucketBackend = "swift"
+	// BackupBucketBackendS3 selects an S3-compatible backend, such as Ceph RGW.
+	BackupBucketBackendS3 BackupBucketBackend = "s3"
+)
+
+// BackupBucketSwift contains Swift-specific configuration for a BackupBucket.
+type BackupBucketSwift struct {
+	// AuthURL is the Keystone authentication URL.
+	AuthURL string
+	// DomainName is the Keystone domain name.
+	DomainName string
+	// ProjectName is the Keystone project (tenant) name.
+	ProjectName string
+}
+
+// BackupBucketS3 contains S3-compatible (e.g. Ceph RGW) configuration for a BackupBucket.
+type BackupBucketS3 struct {
+	// Endpoint is the S3 API endpoint.
+	Endpoint string
+	// Region is the region passed to the S3 API. Independent of BackupBucketProvider.Region, which describes
+	// the OpenStack region the bucket's cloudprovider credentials authenticate against.
+	Region string
+	// PathStyle selects path-style bucket addressing (https://endpoint/bucket) instead of the default
+	// virtual-hosted-style addressing (https://bucket.endpoint). Ceph RGW deployments typically require this.
+	PathStyle bool
+	// SignatureVersion is the S3 request signing version, e.g. "v2" or "v4".
+	SignatureVersion string
+}
+
+// BackupBucketRetentionMode is the mode of a BackupBucketRetentionPolicy.
+type BackupBucketRetentionMode string
+
+const (
+	// BackupBucketRetentionGovernance allows the retention period to be shortened or the objects to be
+	// deleted by a user holding the Swift account owner's credentials.
+	BackupBucketRetentionGovernance BackupBucketRetentionMode = "governance"
+	// BackupBucketRetentionCompliance forbids shortening the retention period or deleting the objects
+	// before it expires, even for the Swift account owner.
+	BackupBucketRetentionCompliance BackupBucketRetentionMode = "compliance"
+)
+
+// BackupBucketRetentionPolicy configures Swift object-lock (WORM) immutability for a BackupBucket.
+type BackupBucketRetentionPolicy struct {
+	// Mode is the retention mode, either "governance" or "compliance".
+	Mode BackupBucketRetentionMode
+	// Duration is the minimum retention period applied to every object written to the bucket.
+	Duration metav1.Duration
+	// LegalHold additionally places the bucket under legal hold, preventing deletion of its objects
+	// irrespective of the retention period.
+	LegalHold bool
+}
+
+// BackupBucketStatus is the provider-specific status of a BackupBucket, surfaced in the generic
+// extensionsv1alpha1.BackupBucket's Status.ProviderStatus.
+type BackupBucketStatus struct {
+	metav1.TypeMeta
+	// RetentionPolicy is the retention policy that was actually applied to the bucket's container on the last
+	// successful reconciliation, which may lag BackupBucketConfig.RetentionPolicy while a change is still being
+	// rolled out.
+	RetentionPolicy *BackupBucketRetentionPolicy
+	// Replication is the observed health of the bucket's cross-region container-sync replication, when
+	// BackupBucketConfig.Replication is set.
+	Replication *BackupBucketReplicationStatus
+}
+
+// BackupBucketReplicationStatus is the observed state of a BackupBucket's cross-region replication.
+type BackupBucketReplicationStatus struct {
+	// Targets is the observed sync state of each replication target declared in BackupBucketReplication.Targets.
+	Targets []BackupBucketReplicationTargetStatus
+	// Conditions represents the latest available observations of the replication's state, e.g.
+	// BackupBucketConditionReplicationHealthy.
+	Conditions []BackupBucketCondition
+}
+
+// BackupBucketReplicationTargetStatus is the observed container-sync state of a single replication target.
+type BackupBucketReplicationTargetStatus struct {
+	// Region is the region of the replication target this status describes.
+	Region string
+	// LastSyncedTime is the timestamp of the most recently synced object observed in the target container. Nil
+	// if no object has been observed synced yet.
+	LastSyncedTime *metav1.Time
+	// BytesLagging is the approximate number of bytes present in the source container that have not yet been
+	// observed in the target container.
+	BytesLagging int64
+}
+
+// BackupBucketConditionType is the type of a BackupBucketCondition.
+type BackupBucketConditionType string
+
+// BackupBucketConditionReplicationHealthy indicates whether every declared replication target is in sync
+// within the configured lag tolerance.
+const BackupBucketConditionReplicationHealthy BackupBucketConditionType = "ReplicationHealthy"
+
+// BackupBucketConditionStatus is the status of a BackupBucketCondition.
+type BackupBucketConditionStatus string
+
+const (
+	// ConditionTrue means the condition is satisfied.
+	ConditionTrue BackupBucketConditionStatus = "True"
+	// ConditionFalse means the condition is not satisfied.
+	ConditionFalse BackupBucketConditionStatus = "False"
+	// ConditionUnknown means the condition could not yet be evaluated, e.g. because no sync has happened yet.
+	ConditionUnknown BackupBucketConditionStatus = "Unknown"
+)
+
+// BackupBucketCondition is a single observation of a BackupBucket's state.
+type BackupBucketCondition struct {
+	// Type is the type of condition, e.g. BackupBucketConditionReplicationHealthy.
+	Type BackupBucketConditionType
+	// Status is the status of the condition, one of ConditionTrue, ConditionFalse, ConditionUnknown.
+	Status BackupBucketConditionStatus
+	// Reason is a short, machine-readable explanation for the condition's status.
+	Reason string
+	// Message is a human-readable explanation for the condition's status.
+	Message string
+	// LastTransitionTime is the time the condition last changed status.
+	LastTransitionTime metav1.Time
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstack
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControlPlaneConfig contains configuration settings for the control plane.
+type ControlPlaneConfig struct {
+	metav1.TypeMeta
+	// LoadBalancerProvider is the name of the load balancer provider in the OpenStack environment.
+	LoadBalancerProvider string
+	// LoadBalancerClasses available for a dedicated Shoot.
+	LoadBalancerClasses []LoadBalancerClass
+	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
+	CloudControllerManager *CloudControllerManagerConfig
+	// CSI contains configuration for CSI drivers deployed for this shoot.
+	CSI *CSI
+	// ApplicationCredentialRotation configures automatic rotation of an application credential managed on
+	// behalf of the shoot, see ManageApplicationCredentialsAnnotation.
+	ApplicationCredentialRotation *ApplicationCredentialRotation
+	// SnapshotClasses declares the VolumeSnapshotClasses to render for this shoot.
+	SnapshotClasses []SnapshotClass
+	// Storage contains overrides for the default Cinder block storage behavior.
+	Storage *Storage
+	// CACertificateConfigMapRef references a ConfigMap in the shoot's control plane namespace whose
+	// "ca-bundle.crt" entry is merged into the trusted CA bundle injected into cloudProviderConfig,
+	// cloudProviderDiskConfig, and the Manila CSI chart, alongside the operator-maintained, cluster-wide
+	// trusted CA bundle and the cloudprovider secret's caCert.
+	CACertificateConfigMapRef *string
+}
+
+// SnapshotClass declares a VolumeSnapshotClass to be rendered for a shoot's Cinder or Manila CSI driver.
+type SnapshotClass struct {
+	// Name is the name of the VolumeSnapshotClass.
+	Name string
+	// Driver is the CSI driver the VolumeSnapshotClass is created for. Defaults to the Cinder CSI driver
+	// (cinder.csi.openstack.org) if empty.
+	Driver string
+	// DeletionPolicy is either "Retain" or "Delete". Defaults to "Delete" if empty.
+	DeletionPolicy string
+	// Default marks this VolumeSnapshotClass as the cluster default, setting the
+	// snapshot.storage.kubernetes.io/is-default-class annotation.
+	Default bool
+	// Parameters are free-form parameters passed through to the VolumeSnapshotClass, e.g. "force-create",
+	// "csi.storage.k8s.io/snapshotter-secret-name", or the Manila-specific "snapshot_type".
+	Parameters map[string]string
+}
+
+// Storage contains overrides for the default Cinder block storage behavior.
+type Storage struct {
+	// BSVersion overrides the Cinder block storage API version used in cloudProviderConfig (bs-version).
+	BSVersion *string
+	// IgnoreVolumeAZ overrides the CloudProfile's default for whether the volume availability zone should be
+	// ignored when scheduling.
+	IgnoreVolumeAZ *bool
+}
+
+// ApplicationCredentialRotation configures automatic rotation of a managed application credential.
+type ApplicationCredentialRotation struct {
+	// RotationPeriod is the interval after which a managed application credential is replaced with a newly
+	// created one. Defaults to 90 days if unset.
+	RotationPeriod *metav1.Duration
+}
+
+// LoadBalancerClass defines a restricted network setting for generic LoadBalancer classes.
+type LoadBalancerClass struct {
+	// Name is the name of the LB class.
+	Name string
+	// FloatingNetworkID is the network ID of the floating network pool.
+	FloatingNetworkID *string
+	// FloatingSubnetID is the subnet ID of the floating ip pool.
+	FloatingSubnetID *string
+	// FloatingSubnetName is the name of the subnet, or a pattern to find it, used to search for the floating
+	// ip pool.
+	FloatingSubnetName *string
+	// FloatingSubnetTags is a list of tags used to search for the floating ip pool.
+	FloatingSubnetTags *string
+	// SubnetID is the ID of a local subnet used for LoadBalancer provisioning.
+	SubnetID *string
+	// Purpose describes the purpose of the LoadBalancerClass, can be "default" to mark it as the default class.
+	Purpose *string
+}
+
+// CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
+type CloudControllerManagerConfig struct {
+	// FeatureGates contains information about enabled feature gates.
+	FeatureGates map[string]bool
+}
+
+// CSI contains configuration for CSI drivers deployed for this shoot.
+type CSI struct {
+	// Manila contains configuration for the Manila CSI driver.
+	Manila *Manila
+}
+
+// Manila contains configuration for the Manila CSI driver.
+type Manila struct {
+	// Enabled determines whether the Manila CSI driver is deployed for this shoot.
+	Enabled bool
+	// ShareTypes declares the Manila share types exposed as StorageClasses for this shoot.
+	ShareTypes []ManilaShareType
+	// ShareNetworks declares the share networks that should be reconciled for this shoot,
+	// superseding the legacy, singular share network.
+	ShareNetworks []ManilaShareNetwork
+}
+
+// ManilaShareType declares a Manila share type and the StorageClass rendered for it.
+type ManilaShareType struct {
+	// Name is the name of the share type, also used as the rendered StorageClass name.
+	Name string
+	// Backend is the Manila share type's backend name as configured in manila.conf.
+	Backend string
+	// ExtraSpecs are extra specs passed through to the Manila share type, e.g. capability
+	// hints consumed by the Manila scheduler.
+	ExtraSpecs map[string]string
+	// DefaultAccessRules are access rules applied to every share provisioned with this type.
+	DefaultAccessRules []ManilaAccessRule
+}
+
+// ManilaAccessRule is a default access rule applied to shares of a ManilaShareType.
+type ManilaAccessRule struct {
+	// AccessType is the Manila access rule type, e.g. "ip" or "cert".
+	AccessType string
+	// AccessTo is the target of the access rule, e.g. a CIDR for "ip" access type.
+	AccessTo string
+	// AccessLevel is either "rw" or "ro".
+	AccessLevel string
+}
+
+// ManilaShareNetwork declares a Manila share network to be reconciled for this shoot.
+type ManilaShareNetwork struct {
+	// Name identifies the share network within the shoot; used to correlate it with its
+	// reconciled status.
+	Name string
+	// SubnetSelector selects the infrastructure subnet the share network should be attached
+	// to, e.g. an availability zone name when zonal subnets are in use.
+	SubnetSelector *string
+	// AZAffinity pins the share network (and the shares created against it) to a specific
+	// availability zone.
+	AZAffinity *string
+}
@@ -0,0 +1,419 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+// BackupBucketConfig contains configuration for a BackupBucket. This is the legacy, flat shape: Region and
+// AvailabilityZone are top-level fields rather than nested under a provider struct, and credentials travel
+// inline rather than through the cloudprovider secret referenced by the generic BackupBucket extension
+// resource. ConvertTo/ConvertFrom bridge this shape to the openstack.BackupBucketConfig hub type.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupBucketConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// Region is the region in which the backup bucket is located.
+	Region string `json:"region"`
+	// AvailabilityZone restricts the bucket to a single Swift availability zone.
+	// +optional
+	AvailabilityZone *string `json:"availabilityZone,omitempty"`
+	// Credentials are the legacy, flat Swift credentials for the backup bucket. Deprecated: new BackupBuckets
+	// should rely on the cloudprovider secret instead; this field exists only so that BackupBuckets created
+	// before the Provider-nested shape was introduced keep decoding correctly.
+	// +optional
+	Credentials *BackupBucketCredentials `json:"credentials,omitempty"`
+	// RetentionPolicy configures Swift object-lock (WORM) immutability for objects written to the backup
+	// bucket. When nil, the bucket is created without retention/legal-hold enabled.
+	// +optional
+	RetentionPolicy *BackupBucketRetentionPolicy `json:"retentionPolicy,omitempty"`
+	// Lifecycle declares per-prefix Swift expiration rules for objects in the backup bucket.
+	// +optional
+	Lifecycle *BackupBucketLifecycle `json:"lifecycle,omitempty"`
+	// Replication configures cross-region Swift container sync for the backup bucket.
+	// +optional
+	Replication *BackupBucketReplication `json:"replication,omitempty"`
+	// Backend selects the object-store backend the bucket is created against. When empty, Swift is assumed.
+	// This field is immutable once the BackupBucket has been created.
+	// +optional
+	Backend BackupBucketBackend `json:"backend,omitempty"`
+	// Swift contains backend-specific configuration for the Swift backend. Only evaluated when Backend is
+	// BackupBucketBackendSwift.
+	// +optional
+	Swift *BackupBucketSwift `json:"swift,omitempty"`
+	// S3 contains backend-specific configuration for the S3-compatible (e.g. Ceph RGW) backend. Only
+	// evaluated when Backend is BackupBucketBackendS3.
+	// +optional
+	S3 *BackupBucketS3 `json:"s3,omitempty"`
+}
+
+// BackupBucketBackend is the object-store backend a BackupBucket is created against.
+type BackupBucketBackend string
+
+const (
+	// BackupBucketBackendSwift selects the OpenStack Swift backend.
+	BackupBucketBackendSwift BackupBucketBackend = "swift"
+	// BackupBucketBackendS3 selects an S3-compatible backend, such as Ceph RGW.
+	BackupBucketBackendS3 BackupBucketBackend = "s3"
+)
+
+// BackupBucketSwift contains Swift-specific configuration for a BackupBucket.
+type BackupBucketSwift struct {
+	// AuthURL is the Keystone authentication URL.
+	AuthURL string `json:"authURL"`
+	// DomainName is the Keystone domain name.
+	DomainName string `json:"domainName"`
+	// ProjectName is the Keystone project (tenant) name.
+	ProjectName string `json:"projectName"`
+}
+
+// BackupBucketS3 contains S3-compatible (e.g. Ceph RGW) configuration for a BackupBucket.
+type BackupBucketS3 struct {
+	// Endpoint is the S3 API endpoint.
+	Endpoint string `json:"endpoint"`
+	// Region is the region passed to the S3 API. Independent of the top-level Region field, which describes
+	// the OpenStack region the bucket's cloudprovider credentials authenticate against.
+	Region string `json:"region"`
+	// PathStyle selects path-style bucket addressing (https://endpoint/bucket) instead of the default
+	// virtual-hosted-style addressing (https://bucket.endpoint). Ceph RGW deployments typically require this.
+	// +optional
+	PathStyle bool `json:"pathStyle,omitempty"`
+	// SignatureVersion is the S3 request signing version, e.g. "v2" or "v4".
+	// +optional
+	SignatureVersion string `json:"signatureVersion,omitempty"`
+}
+
+// BackupBucketLifecycle declares per-prefix Swift expiration rules for objects in a BackupBucket.
+type BackupBucketLifecycle struct {
+	// Rules are the individual lifecycle rules, one per object key prefix.
+	Rules []BackupBucketLifecycleRule `json:"rules,omitempty"`
+}
+
+// BackupBucketLifecycleRule declares the expiration behavior for objects matching a given key prefix.
+type BackupBucketLifecycleRule struct {
+	// Prefix is the object key prefix this rule applies to. An empty prefix matches all objects.
+	Prefix string `json:"prefix"`
+	// DeleteAfter is the age at which matching objects are deleted. When nil, matching objects are never
+	// deleted by this rule.
+	// +optional
+	DeleteAfter *metav1.Duration `json:"deleteAfter,omitempty"`
+	// TransitionToColdAfter is the age at which matching objects are moved to cold storage. When nil,
+	// matching objects are never transitioned by this rule.
+	// +optional
+	TransitionToColdAfter *metav1.Duration `json:"transitionToColdAfter,omitempty"`
+}
+
+// BackupBucketReplication configures cross-region Swift container sync for a BackupBucket.
+type BackupBucketReplication struct {
+	// Targets lists the peer regions this backup bucket is replicated to.
+	Targets []BackupBucketReplicationTarget `json:"targets,omitempty"`
+}
+
+// BackupBucketReplicationTarget is a single cross-region Swift container-sync peer.
+type BackupBucketReplicationTarget struct {
+	// Region is the region of the companion container this bucket is synced to.
+	Region string `json:"region"`
+	// SecretRef references the secret holding the credentials used to authenticate against the target
+	// region's Swift endpoint.
+	SecretRef corev1.SecretReference `json:"secretRef"`
+}
+
+// BackupBucketRetentionMode is the mode of a BackupBucketRetentionPolicy.
+type BackupBucketRetentionMode string
+
+const (
+	// BackupBucketRetentionGovernance allows the retention period to be shortened or the objects to be
+	// deleted by a user holding the Swift account owner's credentials.
+	BackupBucketRetentionGovernance BackupBucketRetentionMode = "governance"
+	// BackupBucketRetentionCompliance forbids shortening the retention period or deleting the objects
+	// before it expires, even for the Swift account owner.
+	BackupBucketRetentionCompliance BackupBucketRetentionMode = "compliance"
+)
+
+// BackupBucketRetentionPolicy configures Swift object-lock (WORM) immutability for a BackupBucket.
+type BackupBucketRetentionPolicy struct {
+	// Mode is the retention mode, either "governance" or "compliance".
+	Mode BackupBucketRetentionMode `json:"mode"`
+	// Duration is the minimum retention period applied to every object written to the bucket.
+	Duration metav1.Duration `json:"duration"`
+	// LegalHold additionally places the bucket under legal hold, preventing deletion of its objects
+	// irrespective of the retention period.
+	// +optional
+	LegalHold bool `json:"legalHold,omitempty"`
+}
+
+// BackupBucketCredentials are the legacy, flat Swift credentials carried inline on a v1alpha1 BackupBucketConfig.
+type BackupBucketCredentials struct {
+	// DomainName is the Keystone domain name.
+	DomainName string `json:"domainName"`
+	// TenantName is the Keystone tenant/project name.
+	TenantName string `json:"tenantName"`
+	// Username is the Keystone username.
+	Username string `json:"username"`
+	// Password is the Keystone password.
+	Password string `json:"password"`
+}
+
+// BackupBucketStatus is the provider-specific status of a BackupBucket, surfaced in the generic
+// extensionsv1alpha1.BackupBucket's Status.ProviderStatus.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupBucketStatus struct {
+	metav1.TypeMeta `json:",inline"`
+	// RetentionPolicy is the retention policy that was actually applied to the bucket's container on the last
+	// successful reconciliation, which may lag BackupBucketConfig.RetentionPolicy while a change is still being
+	// rolled out.
+	// +optional
+	RetentionPolicy *BackupBucketRetentionPolicy `json:"retentionPolicy,omitempty"`
+	// Replication is the observed health of the bucket's cross-region container-sync replication, when
+	// BackupBucketConfig.Replication is set.
+	// +optional
+	Replication *BackupBucketReplicationStatus `json:"replication,omitempty"`
+}
+
+// BackupBucketReplicationStatus is the observed state of a BackupBucket's cross-region replication.
+type BackupBucketReplicationStatus struct {
+	// Targets is the observed sync state of each replication target declared in BackupBucketReplication.Targets.
+	// +optional
+	Targets []BackupBucketReplicationTargetStatus `json:"targets,omitempty"`
+	// Conditions represents the latest available observations of the replication's state, e.g.
+	// BackupBucketConditionReplicationHealthy.
+	// +optional
+	Conditions []BackupBucketCondition `json:"conditions,omitempty"`
+}
+
+// BackupBucketReplicationTargetStatus is the observed container-sync state of a single replication target.
+type BackupBucketReplicationTargetStatus struct {
+	// Region is the region of the replication target this status describes.
+	Region string `json:"region"`
+	// LastSyncedTime is the timestamp of the most recently synced object observed in the target container. Nil
+	// if no object has been observed synced yet.
+	// +optional
+	LastSyncedTime *metav1.Time `json:"lastSyncedTime,omitempty"`
+	// BytesLagging is the approximate number of bytes present in the source container that have not yet been
+	// observed in the target container.
+	// +optional
+	BytesLagging int64 `json:"bytesLagging,omitempty"`
+}
+
+// BackupBucketConditionType is the type of a BackupBucketCondition.
+type BackupBucketConditionType string
+
+// BackupBucketConditionReplicationHealthy indicates whether every declared replication target is in sync
+// within the configured lag tolerance.
+const BackupBucketConditionReplicationHealthy BackupBucketConditionType = "ReplicationHealthy"
+
+// BackupBucketConditionStatus is the status of a BackupBucketCondition.
+type BackupBucketConditionStatus string
+
+const (
+	// ConditionTrue means the condition is satisfied.
+	ConditionTrue BackupBucketConditionStatus = "True"
+	// ConditionFalse means the condition is not satisfied.
+	ConditionFalse BackupBucketConditionStatus = "False"
+	// ConditionUnknown means the condition could not yet be evaluated, e.g. because no sync has happened yet.
+	ConditionUnknown BackupBucketConditionStatus = "Unknown"
+)
+
+// BackupBucketCondition is a single observation of a BackupBucket's state.
+type BackupBucketCondition struct {
+	// Type is the type of condition, e.g. BackupBucketConditionReplicationHealthy.
+	Type BackupBucketConditionType `json:"type"`
+	// Status is the status of the condition, one of ConditionTrue, ConditionFalse, ConditionUnknown.
+	Status BackupBucketConditionStatus `json:"status"`
+	// Reason is a short, machine-readable explanation for the condition's status.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable explanation for the condition's status.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the time the condition last changed status.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// ConvertTo converts this BackupBucketStatus to the openstack.BackupBucketStatus hub type.
+func (in *BackupBucketStatus) ConvertTo(out *openstack.BackupBucketStatus) error {
+	out.TypeMeta = in.TypeMeta
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = &openstack.BackupBucketRetentionPolicy{
+			Mode:      openstack.BackupBucketRetentionMode(in.RetentionPolicy.Mode),
+			Duration:  in.RetentionPolicy.Duration,
+			LegalHold: in.RetentionPolicy.LegalHold,
+		}
+	}
+	if in.Replication != nil {
+		out.Replication = &openstack.BackupBucketReplicationStatus{}
+		for _, target := range in.Replication.Targets {
+			out.Replication.Targets = append(out.Replication.Targets, openstack.BackupBucketReplicationTargetStatus{
+				Region:         target.Region,
+				LastSyncedTime: target.LastSyncedTime,
+				BytesLagging:   target.BytesLagging,
+			})
+		}
+		for _, cond := range in.Replication.Conditions {
+			out.Replication.Conditions = append(out.Replication.Conditions, openstack.BackupBucketCondition{
+				Type:               openstack.BackupBucketConditionType(cond.Type),
+				Status:             openstack.BackupBucketConditionStatus(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime,
+			})
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts the openstack.BackupBucketStatus hub type back to this versioned shape.
+func (in *BackupBucketStatus) ConvertFrom(hub *openstack.BackupBucketStatus) error {
+	in.TypeMeta = hub.TypeMeta
+	if hub.RetentionPolicy != nil {
+		in.RetentionPolicy = &BackupBucketRetentionPolicy{
+			Mode:      BackupBucketRetentionMode(hub.RetentionPolicy.Mode),
+			Duration:  hub.RetentionPolicy.Duration,
+			LegalHold: hub.RetentionPolicy.LegalHold,
+		}
+	}
+	if hub.Replication != nil {
+		in.Replication = &BackupBucketReplicationStatus{}
+		for _, target := range hub.Replication.Targets {
+			in.Replication.Targets = append(in.Replication.Targets, BackupBucketReplicationTargetStatus{
+				Region:         target.Region,
+				LastSyncedTime: target.LastSyncedTime,
+				BytesLagging:   target.BytesLagging,
+			})
+		}
+		for _, cond := range hub.Replication.Conditions {
+			in.Replication.Conditions = append(in.Replication.Conditions, BackupBucketCondition{
+				Type:               BackupBucketConditionType(cond.Type),
+				Status:             BackupBucketConditionStatus(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime,
+			})
+		}
+	}
+	return nil
+}
+
+// ConvertTo converts this legacy, flat BackupBucketConfig to the openstack.BackupBucketConfig hub type. The
+// inline Credentials, if present, are dropped: the hub type only ever sources credentials from the
+// cloudprovider secret, never from providerConfig.
+func (in *BackupBucketConfig) ConvertTo(out *openstack.BackupBucketConfig) error {
+	out.TypeMeta = in.TypeMeta
+	out.Provider = openstack.BackupBucketProvider{
+		Region:           in.Region,
+		AvailabilityZone: in.AvailabilityZone,
+		Backend:          openstack.BackupBucketBackend(in.Backend),
+	}
+	if in.Swift != nil {
+		out.Provider.Swift = &openstack.BackupBucketSwift{
+			AuthURL:     in.Swift.AuthURL,
+			DomainName:  in.Swift.DomainName,
+			ProjectName: in.Swift.ProjectName,
+		}
+	}
+	if in.S3 != nil {
+		out.Provider.S3 = &openstack.BackupBucketS3{
+			Endpoint:         in.S3.Endpoint,
+			Region:           in.S3.Region,
+			PathStyle:        in.S3.PathStyle,
+			SignatureVersion: in.S3.SignatureVersion,
+		}
+	}
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = &openstack.BackupBucketRetentionPolicy{
+			Mode:      openstack.BackupBucketRetentionMode(in.RetentionPolicy.Mode),
+			Duration:  in.RetentionPolicy.Duration,
+			LegalHold: in.RetentionPolicy.LegalHold,
+		}
+	}
+	if in.Lifecycle != nil {
+		rules := make([]openstack.BackupBucketLifecycleRule, 0, len(in.Lifecycle.Rules))
+		for _, rule := range in.Lifecycle.Rules {
+			rules = append(rules, openstack.BackupBucketLifecycleRule{
+				Prefix:                rule.Prefix,
+				DeleteAfter:           rule.DeleteAfter,
+				TransitionToColdAfter: rule.TransitionToColdAfter,
+			})
+		}
+		out.Lifecycle = &openstack.BackupBucketLifecycle{Rules: rules}
+	}
+	if in.Replication != nil {
+		targets := make([]openstack.BackupBucketReplicationTarget, 0, len(in.Replication.Targets))
+		for _, target := range in.Replication.Targets {
+			targets = append(targets, openstack.BackupBucketReplicationTarget{
+				Region:    target.Region,
+				SecretRef: target.SecretRef,
+			})
+		}
+		out.Replication = &openstack.BackupBucketReplication{Targets: targets}
+	}
+	return nil
+}
+
+// ConvertFrom converts the openstack.BackupBucketConfig hub type back to this legacy, flat shape. Credentials
+// is left nil: the hub type carries no inline credentials to convert back.
+func (in *BackupBucketConfig) ConvertFrom(hub *openstack.BackupBucketConfig) error {
+	in.TypeMeta = hub.TypeMeta
+	in.Region = hub.Provider.Region
+	in.AvailabilityZone = hub.Provider.AvailabilityZone
+	in.Backend = BackupBucketBackend(hub.Provider.Backend)
+	if hub.Provider.Swift != nil {
+		in.Swift = &BackupBucketSwift{
+			AuthURL:     hub.Provider.Swift.AuthURL,
+			DomainName:  hub.Provider.Swift.DomainName,
+			ProjectName: hub.Provider.Swift.ProjectName,
+		}
+	}
+	if hub.Provider.S3 != nil {
+		in.S3 = &BackupBucketS3{
+			Endpoint:         hub.Provider.S3.Endpoint,
+			Region:           hub.Provider.S3.Region,
+			PathStyle:        hub.Provider.S3.PathStyle,
+			SignatureVersion: hub.Provider.S3.SignatureVersion,
+		}
+	}
+	if hub.RetentionPolicy != nil {
+		in.RetentionPolicy = &BackupBucketRetentionPolicy{
+			Mode:      BackupBucketRetentionMode(hub.RetentionPolicy.Mode),
+			Duration:  hub.RetentionPolicy.Duration,
+			LegalHold: hub.RetentionPolicy.LegalHold,
+		}
+	}
+	if hub.Lifecycle != nil {
+		rules := make([]BackupBucketLifecycleRule, 0, len(hub.Lifecycle.Rules))
+		for _, rule := range hub.Lifecycle.Rules {
+			rules = append(rules, BackupBucketLifecycleRule{
+				Prefix:                rule.Prefix,
+				DeleteAfter:           rule.DeleteAfter,
+				TransitionToColdAfter: rule.TransitionToColdAfter,
+			})
+		}
+		in.Lifecycle = &BackupBucketLifecycle{Rules: rules}
+	}
+	if hub.Replication != nil {
+		targets := make([]BackupBucketReplicationTarget, 0, len(hub.Replication.Targets))
+		for _, target := range hub.Replication.Targets {
+			targets = append(targets, BackupBucketReplicationTarget{
+				Region:    target.Region,
+				SecretRef: target.SecretRef,
+			})
+		}
+		in.Replication = &BackupBucketReplication{Targets: targets}
+	}
+	return nil
+}
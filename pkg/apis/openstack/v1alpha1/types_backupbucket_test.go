@@ -0,0 +1,232 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+var _ = Describe("BackupBucketConfig conversion", func() {
+	DescribeTable("ConvertTo/ConvertFrom round-trip",
+		func(in *BackupBucketConfig) {
+			hub := &openstack.BackupBucketConfig{}
+			Expect(in.ConvertTo(hub)).To(Succeed())
+
+			Expect(hub.Provider.Region).To(Equal(in.Region))
+			if in.AvailabilityZone == nil {
+				Expect(hub.Provider.AvailabilityZone).To(BeNil())
+			} else {
+				Expect(hub.Provider.AvailabilityZone).To(HaveValue(Equal(*in.AvailabilityZone)))
+			}
+
+			back := &BackupBucketConfig{}
+			Expect(back.ConvertFrom(hub)).To(Succeed())
+
+			Expect(back.Region).To(Equal(in.Region))
+			if in.AvailabilityZone == nil {
+				Expect(back.AvailabilityZone).To(BeNil())
+			} else {
+				Expect(back.AvailabilityZone).To(HaveValue(Equal(*in.AvailabilityZone)))
+			}
+			Expect(back.Credentials).To(BeNil(), "the hub type carries no inline credentials")
+			if in.RetentionPolicy == nil {
+				Expect(back.RetentionPolicy).To(BeNil())
+			} else {
+				Expect(back.RetentionPolicy).To(HaveValue(Equal(*in.RetentionPolicy)))
+			}
+		},
+		Entry("region only", &BackupBucketConfig{Region: "eu-de-1"}),
+		Entry("region and availability zone", &BackupBucketConfig{Region: "eu-de-1", AvailabilityZone: ptrTo("az-1")}),
+		Entry("legacy inline credentials are dropped by ConvertTo and not reconstructed by ConvertFrom",
+			&BackupBucketConfig{
+				Region: "eu-de-1",
+				Credentials: &BackupBucketCredentials{
+					DomainName: "domain",
+					TenantName: "tenant",
+					Username:   "user",
+					Password:   "s3cret",
+				},
+			},
+		),
+		Entry("retention policy round-trips",
+			&BackupBucketConfig{
+				Region: "eu-de-1",
+				RetentionPolicy: &BackupBucketRetentionPolicy{
+					Mode:      BackupBucketRetentionCompliance,
+					Duration:  metav1.Duration{Duration: 24 * time.Hour},
+					LegalHold: true,
+				},
+			},
+		),
+	)
+
+	It("round-trips Lifecycle and Replication", func() {
+		in := &BackupBucketConfig{
+			Region: "eu-de-1",
+			Lifecycle: &BackupBucketLifecycle{
+				Rules: []BackupBucketLifecycleRule{
+					{Prefix: "etcd/", TransitionToColdAfter: &metav1.Duration{Duration: 24 * time.Hour}, DeleteAfter: &metav1.Duration{Duration: 7 * 24 * time.Hour}},
+					{Prefix: ""},
+				},
+			},
+			Replication: &BackupBucketReplication{
+				Targets: []BackupBucketReplicationTarget{
+					{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "swift-sync-eu-de-2", Namespace: "garden"}},
+				},
+			},
+		}
+
+		hub := &openstack.BackupBucketConfig{}
+		Expect(in.ConvertTo(hub)).To(Succeed())
+
+		Expect(hub.Lifecycle).To(Equal(&openstack.BackupBucketLifecycle{
+			Rules: []openstack.BackupBucketLifecycleRule{
+				{Prefix: "etcd/", TransitionToColdAfter: &metav1.Duration{Duration: 24 * time.Hour}, DeleteAfter: &metav1.Duration{Duration: 7 * 24 * time.Hour}},
+				{Prefix: ""},
+			},
+		}))
+		Expect(hub.Replication).To(Equal(&openstack.BackupBucketReplication{
+			Targets: []openstack.BackupBucketReplicationTarget{
+				{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "swift-sync-eu-de-2", Namespace: "garden"}},
+			},
+		}))
+
+		back := &BackupBucketConfig{}
+		Expect(back.ConvertFrom(hub)).To(Succeed())
+		Expect(back.Lifecycle).To(Equal(in.Lifecycle))
+		Expect(back.Replication).To(Equal(in.Replication))
+	})
+
+	DescribeTable("ConvertTo/ConvertFrom round-trip for Backend",
+		func(in *BackupBucketConfig) {
+			hub := &openstack.BackupBucketConfig{}
+			Expect(in.ConvertTo(hub)).To(Succeed())
+			Expect(hub.Provider.Backend).To(Equal(openstack.BackupBucketBackend(in.Backend)))
+
+			back := &BackupBucketConfig{}
+			Expect(back.ConvertFrom(hub)).To(Succeed())
+			Expect(back.Backend).To(Equal(in.Backend))
+			Expect(back.Swift).To(Equal(in.Swift))
+			Expect(back.S3).To(Equal(in.S3))
+		},
+		Entry("swift backend", &BackupBucketConfig{
+			Region:  "eu-de-1",
+			Backend: BackupBucketBackendSwift,
+			Swift:   &BackupBucketSwift{AuthURL: "https://keystone.example.com/v3", DomainName: "default", ProjectName: "my-project"},
+		}),
+		Entry("s3 backend", &BackupBucketConfig{
+			Region:  "eu-de-1",
+			Backend: BackupBucketBackendS3,
+			S3:      &BackupBucketS3{Endpoint: "https://rgw.example.com", Region: "default", PathStyle: true, SignatureVersion: "v4"},
+		}),
+	)
+
+	// These entries are not generated by a fuzzer (no property-based fuzz framework is vendored in this repo);
+	// they are hand-picked to exercise combinations the table-by-table tests above don't: every optional field
+	// left unset at once, every optional field set at once, and a config carrying multiple lifecycle rules and
+	// replication targets together. A conversion-gen-generated fuzz test and a conversion webhook (to keep the
+	// hub and v1alpha1 shapes reconcilable as fields are added) do not exist for this API group; this extension
+	// has no codegen pipeline and only ever had the one external version, so round-trip coverage is maintained
+	// by hand here instead.
+	DescribeTable("ConvertTo/ConvertFrom round-trip (hand-picked fuzz-style coverage)",
+		func(in *BackupBucketConfig) {
+			hub := &openstack.BackupBucketConfig{}
+			Expect(in.ConvertTo(hub)).To(Succeed())
+
+			back := &BackupBucketConfig{}
+			Expect(back.ConvertFrom(hub)).To(Succeed())
+
+			back.Credentials = in.Credentials // the hub type never carries inline credentials; see above
+			Expect(back).To(Equal(in))
+		},
+		Entry("zero value", &BackupBucketConfig{}),
+		Entry("every optional field unset but Region", &BackupBucketConfig{Region: "eu-de-1"}),
+		Entry("every optional field set at once", &BackupBucketConfig{
+			Region:           "eu-de-1",
+			AvailabilityZone: ptrTo("az-1"),
+			RetentionPolicy: &BackupBucketRetentionPolicy{
+				Mode:      BackupBucketRetentionCompliance,
+				Duration:  metav1.Duration{Duration: 24 * time.Hour},
+				LegalHold: true,
+			},
+			Lifecycle: &BackupBucketLifecycle{
+				Rules: []BackupBucketLifecycleRule{
+					{Prefix: "etcd/", DeleteAfter: &metav1.Duration{Duration: 7 * 24 * time.Hour}},
+				},
+			},
+			Replication: &BackupBucketReplication{
+				Targets: []BackupBucketReplicationTarget{
+					{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "swift-sync-eu-de-2", Namespace: "garden"}},
+				},
+			},
+			Backend: BackupBucketBackendS3,
+			S3:      &BackupBucketS3{Endpoint: "https://rgw.example.com", Region: "default", PathStyle: true, SignatureVersion: "v4"},
+		}),
+		Entry("multiple lifecycle rules and replication targets", &BackupBucketConfig{
+			Region: "eu-de-1",
+			Lifecycle: &BackupBucketLifecycle{
+				Rules: []BackupBucketLifecycleRule{
+					{Prefix: "etcd/", TransitionToColdAfter: &metav1.Duration{Duration: 24 * time.Hour}},
+					{Prefix: "logs/", DeleteAfter: &metav1.Duration{Duration: 30 * 24 * time.Hour}},
+					{Prefix: ""},
+				},
+			},
+			Replication: &BackupBucketReplication{
+				Targets: []BackupBucketReplicationTarget{
+					{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "a", Namespace: "garden"}},
+					{Region: "eu-nl-1", SecretRef: corev1.SecretReference{Name: "b", Namespace: "garden"}},
+				},
+			},
+		}),
+	)
+})
+
+var _ = Describe("BackupBucketStatus conversion", func() {
+	DescribeTable("ConvertTo/ConvertFrom round-trip",
+		func(in *BackupBucketStatus) {
+			hub := &openstack.BackupBucketStatus{}
+			Expect(in.ConvertTo(hub)).To(Succeed())
+
+			back := &BackupBucketStatus{}
+			Expect(back.ConvertFrom(hub)).To(Succeed())
+			Expect(back).To(Equal(in))
+		},
+		Entry("empty status", &BackupBucketStatus{}),
+		Entry("retention policy only", &BackupBucketStatus{
+			RetentionPolicy: &BackupBucketRetentionPolicy{Mode: BackupBucketRetentionCompliance, Duration: metav1.Duration{Duration: time.Hour}},
+		}),
+		Entry("replication status with targets and conditions", &BackupBucketStatus{
+			Replication: &BackupBucketReplicationStatus{
+				Targets: []BackupBucketReplicationTargetStatus{
+					{Region: "eu-de-2", LastSyncedTime: &metav1.Time{Time: time.Unix(0, 0).UTC()}, BytesLagging: 1024},
+				},
+				Conditions: []BackupBucketCondition{
+					{Type: BackupBucketConditionReplicationHealthy, Status: ConditionFalse, Reason: "TargetsLagging", Message: "lagging"},
+				},
+			},
+		}),
+	)
+})
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControlPlaneConfig contains configuration settings for the control plane.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ControlPlaneConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// LoadBalancerProvider is the name of the load balancer provider in the OpenStack environment.
+	LoadBalancerProvider string `json:"loadBalancerProvider"`
+	// LoadBalancerClasses available for a dedicated Shoot.
+	// +optional
+	LoadBalancerClasses []LoadBalancerClass `json:"loadBalancerClasses,omitempty"`
+	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
+	// +optional
+	CloudControllerManager *CloudControllerManagerConfig `json:"cloudControllerManager,omitempty"`
+}
+
+// LoadBalancerClass defines a restricted network setting for generic LoadBalancer classes.
+type LoadBalancerClass struct {
+	// Name is the name of the LB class.
+	Name string `json:"name"`
+	// FloatingNetworkID is the network ID of the floating network pool.
+	// +optional
+	FloatingNetworkID *string `json:"floatingNetworkID,omitempty"`
+	// FloatingSubnetID is the subnet ID of the floating ip pool.
+	// +optional
+	FloatingSubnetID *string `json:"floatingSubnetID,omitempty"`
+	// FloatingSubnetName is the name of the subnet, or a pattern to find it, used to search for the floating
+	// ip pool.
+	// +optional
+	FloatingSubnetName *string `json:"floatingSubnetName,omitempty"`
+	// FloatingSubnetTags is a list of tags used to search for the floating ip pool.
+	// +optional
+	FloatingSubnetTags *string `json:"floatingSubnetTags,omitempty"`
+	// SubnetID is the ID of a local subnet used for LoadBalancer provisioning.
+	// +optional
+	SubnetID *string `json:"subnetID,omitempty"`
+	// Purpose describes the purpose of the LoadBalancerClass, can be "default" to mark it as the default class.
+	// +optional
+	Purpose *string `json:"purpose,omitempty"`
+}
+
+// CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
+type CloudControllerManagerConfig struct {
+	// FeatureGates contains information about enabled feature gates.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// CloudProfileConfig contains provider-specific configuration that is embedded into Gardener's `CloudProfile`
+// resource.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CloudProfileConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// KeyStoneURL is the URL for the OpenStack Keystone service.
+	// +optional
+	KeyStoneURL string `json:"keyStoneURL,omitempty"`
+	// Constraints is an object containing constraints for certain values in the control plane config.
+	Constraints Constraints `json:"constraints"`
+}
+
+// Constraints is an object containing constraints for the shoots.
+type Constraints struct {
+	// FloatingPools contains constraints regarding allowed values for the 'floatingPoolName' block in the control
+	// plane config.
+	FloatingPools []FloatingPool `json:"floatingPools"`
+}
+
+// FloatingPool contains constraints regarding allowed values of the 'floatingPoolName' block in the control plane
+// config.
+type FloatingPool struct {
+	// Name is the name of the floating pool.
+	Name string `json:"name"`
+	// Region is the region name.
+	// +optional
+	Region *string `json:"region,omitempty"`
+	// DefaultFloatingSubnet is the default floating subnet for this floating pool.
+	// +optional
+	DefaultFloatingSubnet *string `json:"defaultFloatingSubnet,omitempty"`
+}
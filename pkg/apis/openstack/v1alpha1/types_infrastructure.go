@@ -0,0 +1,215 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InfrastructureConfig infrastructure configuration resource.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type InfrastructureConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// FloatingPoolName contains the name of the floating pool to be used.
+	FloatingPoolName string `json:"floatingPoolName"`
+	// FloatingPoolSubnetName contains the name or id of the floating pool subnet to be used.
+	// +optional
+	FloatingPoolSubnetName *string `json:"floatingPoolSubnetName,omitempty"`
+	// Networks is the OpenStack specific network configuration.
+	Networks Networks `json:"networks"`
+}
+
+// Networks holds information about the Kubernetes and infrastructure networks.
+type Networks struct {
+	// ID is the ID of an existing private network.
+	// +optional
+	ID *string `json:"id,omitempty"`
+	// Router indicates whether to use an existing router.
+	// +optional
+	Router *Router `json:"router,omitempty"`
+	// Workers is a CIDRs of a worker subnet (private) to create.
+	Workers string `json:"workers"`
+	// ShareNetwork holds the configuration for the Manila share network.
+	// +optional
+	ShareNetwork *ShareNetwork `json:"shareNetwork,omitempty"`
+	// ShareNetworks declares additional, named Manila share networks for the infrastructure controller to
+	// reconcile against Neutron, superseding ShareNetwork for setups that need more than one (e.g. to satisfy
+	// per-availability-zone AZAffinity declared in ControlPlaneConfig.CSI.Manila.ShareNetworks).
+	// +optional
+	ShareNetworks []NamedShareNetwork `json:"shareNetworks,omitempty"`
+	// Zones holds per-availability-zone worker network configuration. When set, it supersedes Workers.
+	// +optional
+	Zones []ZoneNetwork `json:"zones,omitempty"`
+}
+
+// ZoneNetwork holds the network configuration for a single availability zone.
+type ZoneNetwork struct {
+	// Name is the name of the availability zone.
+	Name string `json:"name"`
+	// WorkerCIDR is the CIDR of the worker subnet to create in this zone.
+	WorkerCIDR string `json:"workerCIDR"`
+	// RouterInterface indicates whether this zone's subnet should be attached via its own
+	// router interface instead of sharing the infrastructure's default router.
+	// +optional
+	RouterInterface *bool `json:"routerInterface,omitempty"`
+}
+
+// Router indicates whether to use an existing router and its id.
+type Router struct {
+	// ID is the router id.
+	ID string `json:"id"`
+	// ExternalFixedIPs are pre-allocated IPs to assign to the router's external gateway port.
+	// +optional
+	ExternalFixedIPs []string `json:"externalFixedIPs,omitempty"`
+	// ReserveFloatingIP indicates that a floating IP should be allocated and reserved for the
+	// router's external gateway, independent of the router's lifecycle.
+	// +optional
+	ReserveFloatingIP *bool `json:"reserveFloatingIP,omitempty"`
+	// FloatingIPID references a pre-existing, operator-provided floating IP to bind to the
+	// router's external gateway (bring-your-own IP).
+	// +optional
+	FloatingIPID *string `json:"floatingIPID,omitempty"`
+}
+
+// ShareNetwork holds the configuration for the Manila share network.
+type ShareNetwork struct {
+	// Enabled indicates if the share network should be created.
+	Enabled bool `json:"enabled"`
+}
+
+// NamedShareNetwork declares a single, named Manila share network to be created by the infrastructure
+// controller, referenced by name from ControlPlaneConfig.CSI.Manila.ShareNetworks.
+type NamedShareNetwork struct {
+	// Name is the name of the share network.
+	Name string `json:"name"`
+	// Zone restricts the share network's subnet to a specific availability zone. When empty, the
+	// infrastructure's primary/default subnet is used.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+}
+
+// InfrastructureStatus contains information about created infrastructure resources.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type InfrastructureStatus struct {
+	metav1.TypeMeta `json:",inline"`
+	// Networks contains information about the created networks and their components.
+	Networks NetworkStatus `json:"networks"`
+	// SecurityGroups is a list of security groups that have been created.
+	SecurityGroups []SecurityGroup `json:"securityGroups"`
+	// Node contains information about Node related resources.
+	Node NodeStatus `json:"node"`
+}
+
+// NetworkStatus contains information about a generated Network, Subnet and related resources.
+type NetworkStatus struct {
+	// ID is the Network id.
+	ID string `json:"id"`
+	// Name is the Network name.
+	Name string `json:"name"`
+	// FloatingPool contains the FloatingPoolStatus
+	FloatingPool FloatingPoolStatus `json:"floatingPool"`
+	// Router contains information about the Router, e.g. ID.
+	Router RouterStatus `json:"router"`
+	// Subnets is a list of subnets that have been created.
+	Subnets []Subnet `json:"subnets"`
+	// ShareNetwork holds the status of the Manila share network, when enabled. Superseded by
+	// ShareNetworks for setups with more than one declared share network.
+	// +optional
+	ShareNetwork *ShareNetworkStatus `json:"shareNetwork,omitempty"`
+	// ShareNetworks contains the status of the Manila share networks reconciled for this shoot.
+	// +optional
+	ShareNetworks []ShareNetworkStatus `json:"shareNetworks,omitempty"`
+	// InternalEndpoint is the internal VIP fronting the API server of a fully-private shoot.
+	// +optional
+	InternalEndpoint string `json:"internalEndpoint,omitempty"`
+	// JumpHost is the bastion used to reach a fully-private shoot, when InternalLoadBalancer is enabled.
+	// +optional
+	JumpHost *JumpHostStatus `json:"jumpHost,omitempty"`
+}
+
+// JumpHostStatus contains information about the bastion VM of a fully-private shoot.
+type JumpHostStatus struct {
+	// IP is the jump host's externally reachable floating IP.
+	IP string `json:"ip"`
+	// SSHKeyName is the name of the SSH key accepted by the jump host.
+	SSHKeyName string `json:"sshKeyName"`
+}
+
+// Subnet holds information about a subnet.
+type Subnet struct {
+	// Purpose is a logical description of the subnet.
+	Purpose Purpose `json:"purpose"`
+	// ID is the subnet id.
+	ID string `json:"id"`
+	// Zone is the availability zone this subnet was created in. Empty for the legacy,
+	// single-subnet layout.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// Purpose is a string alias.
+type Purpose string
+
+const (
+	// PurposeNodes is the purpose for the worker/node subnet.
+	PurposeNodes Purpose = "nodes"
+)
+
+// FloatingPoolStatus contains information about the floating pool.
+type FloatingPoolStatus struct {
+	// ID is the floating pool id.
+	ID string `json:"id"`
+	// Name is the floating pool name.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// RouterStatus contains information about the Router and related resources.
+type RouterStatus struct {
+	// ID is the Router id.
+	ID string `json:"id"`
+	// IP is the router's internal gateway IP.
+	// +optional
+	IP string `json:"ip,omitempty"`
+	// PublicIP is the stable, externally reachable IP assigned to the router's external
+	// gateway. It is tracked separately from IP so that it can be reserved and kept stable
+	// across router recreations.
+	// +optional
+	PublicIP string `json:"publicIP,omitempty"`
+}
+
+// SecurityGroup is a NetworkStatus's security group configuration.
+type SecurityGroup struct {
+	// Purpose is a logical description of the security group.
+	Purpose Purpose `json:"purpose"`
+	// ID is the security group id.
+	ID string `json:"id"`
+	// Name is the security group name.
+	Name string `json:"name"`
+}
+
+// NodeStatus contains information about Node related resources.
+type NodeStatus struct {
+	// KeyName is the name of the SSH key.
+	KeyName string `json:"keyName"`
+}
+
+// ShareNetworkStatus contains the status of the Manila share network.
+type ShareNetworkStatus struct {
+	// Name is the name of the share network.
+	Name string `json:"name"`
+	// ID is the id of the share network.
+	ID string `json:"id"`
+}
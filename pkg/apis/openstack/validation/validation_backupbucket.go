@@ -0,0 +1,67 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validation contains functions to validate the OpenStack provider APIs.
+package validation
+
+import (
+	"fmt"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+// ValidateBackupBucketRetentionPolicy validates a BackupBucketRetentionPolicy.
+func ValidateBackupBucketRetentionPolicy(policy *api.BackupBucketRetentionPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	switch policy.Mode {
+	case api.BackupBucketRetentionGovernance, api.BackupBucketRetentionCompliance:
+	default:
+		return fmt.Errorf("retention policy mode must be %q or %q, got %q",
+			api.BackupBucketRetentionGovernance, api.BackupBucketRetentionCompliance, policy.Mode)
+	}
+
+	if policy.Duration.Duration <= 0 {
+		return fmt.Errorf("retention policy duration must be greater than zero")
+	}
+
+	return nil
+}
+
+// ValidateBackupBucketRetentionPolicyUpdate validates that an update to a BackupBucketRetentionPolicy does
+// not shorten the effective retention: once compliance mode is applied it can never be removed or downgraded
+// to governance mode, and the retention duration can never be reduced.
+func ValidateBackupBucketRetentionPolicyUpdate(oldPolicy, newPolicy *api.BackupBucketRetentionPolicy) error {
+	if oldPolicy == nil {
+		return nil
+	}
+
+	if oldPolicy.Mode == api.BackupBucketRetentionCompliance {
+		if newPolicy == nil {
+			return fmt.Errorf("retention policy is immutable once compliance mode is applied: cannot be removed")
+		}
+		if newPolicy.Mode != api.BackupBucketRetentionCompliance {
+			return fmt.Errorf("retention policy is immutable once compliance mode is applied: cannot switch to mode %q", newPolicy.Mode)
+		}
+	}
+
+	if newPolicy != nil && newPolicy.Duration.Duration < oldPolicy.Duration.Duration {
+		return fmt.Errorf("retention policy duration cannot be shortened from %s to %s",
+			oldPolicy.Duration.Duration, newPolicy.Duration.Duration)
+	}
+
+	return nil
+}
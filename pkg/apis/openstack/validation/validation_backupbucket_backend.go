@@ -0,0 +1,62 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+// ValidateBackupBucketProvider validates a BackupBucketProvider.
+func ValidateBackupBucketProvider(provider *api.BackupBucketProvider) error {
+	switch provider.Backend {
+	case "", api.BackupBucketBackendSwift:
+		if provider.S3 != nil {
+			return fmt.Errorf("backend %q must not set s3 configuration", provider.Backend)
+		}
+	case api.BackupBucketBackendS3:
+		if provider.Swift != nil {
+			return fmt.Errorf("backend %q must not set swift configuration", provider.Backend)
+		}
+		if provider.S3 == nil {
+			return fmt.Errorf("backend %q requires s3 configuration", provider.Backend)
+		}
+		if provider.S3.Endpoint == "" {
+			return fmt.Errorf("s3 endpoint must not be empty")
+		}
+	default:
+		return fmt.Errorf("backend must be %q or %q, got %q", api.BackupBucketBackendSwift, api.BackupBucketBackendS3, provider.Backend)
+	}
+
+	return nil
+}
+
+// ValidateBackupBucketProviderUpdate validates that an update to a BackupBucketProvider does not change the
+// backend: the backend is fixed for the lifetime of the bucket.
+func ValidateBackupBucketProviderUpdate(oldProvider, newProvider *api.BackupBucketProvider) error {
+	oldBackend, newBackend := effectiveBackend(oldProvider), effectiveBackend(newProvider)
+	if oldBackend != newBackend {
+		return fmt.Errorf("backend is immutable: cannot change from %q to %q", oldBackend, newBackend)
+	}
+	return nil
+}
+
+func effectiveBackend(provider *api.BackupBucketProvider) api.BackupBucketBackend {
+	if provider.Backend == "" {
+		return api.BackupBucketBackendSwift
+	}
+	return provider.Backend
+}
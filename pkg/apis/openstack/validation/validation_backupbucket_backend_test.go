@@ -0,0 +1,73 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+var _ = Describe("ValidateBackupBucketProvider", func() {
+	DescribeTable("validating a BackupBucketProvider",
+		func(provider *api.BackupBucketProvider, wantErr bool) {
+			err := ValidateBackupBucketProvider(provider)
+			if wantErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("empty backend defaults to swift and is valid", &api.BackupBucketProvider{Region: "eu-de-1"}, false),
+		Entry("explicit swift backend", &api.BackupBucketProvider{Region: "eu-de-1", Backend: api.BackupBucketBackendSwift}, false),
+		Entry("swift backend with s3 config is invalid",
+			&api.BackupBucketProvider{Backend: api.BackupBucketBackendSwift, S3: &api.BackupBucketS3{Endpoint: "https://rgw.example.com"}}, true),
+		Entry("valid s3 backend",
+			&api.BackupBucketProvider{Backend: api.BackupBucketBackendS3, S3: &api.BackupBucketS3{Endpoint: "https://rgw.example.com"}}, false),
+		Entry("s3 backend without s3 config is invalid",
+			&api.BackupBucketProvider{Backend: api.BackupBucketBackendS3}, true),
+		Entry("s3 backend with empty endpoint is invalid",
+			&api.BackupBucketProvider{Backend: api.BackupBucketBackendS3, S3: &api.BackupBucketS3{}}, true),
+		Entry("s3 backend with swift config is invalid",
+			&api.BackupBucketProvider{Backend: api.BackupBucketBackendS3, Swift: &api.BackupBucketSwift{AuthURL: "https://keystone.example.com"}, S3: &api.BackupBucketS3{Endpoint: "https://rgw.example.com"}}, true),
+		Entry("unknown backend is invalid", &api.BackupBucketProvider{Backend: "minio"}, true),
+	)
+
+	Describe("ValidateBackupBucketProviderUpdate", func() {
+		DescribeTable("validating an update to a BackupBucketProvider",
+			func(oldProvider, newProvider *api.BackupBucketProvider, wantErr bool) {
+				err := ValidateBackupBucketProviderUpdate(oldProvider, newProvider)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("unchanged explicit backend",
+				&api.BackupBucketProvider{Backend: api.BackupBucketBackendSwift},
+				&api.BackupBucketProvider{Backend: api.BackupBucketBackendSwift},
+				false),
+			Entry("empty backend is equivalent to swift",
+				&api.BackupBucketProvider{},
+				&api.BackupBucketProvider{Backend: api.BackupBucketBackendSwift},
+				false),
+			Entry("changing backend is forbidden",
+				&api.BackupBucketProvider{Backend: api.BackupBucketBackendSwift},
+				&api.BackupBucketProvider{Backend: api.BackupBucketBackendS3},
+				true),
+		)
+	})
+})
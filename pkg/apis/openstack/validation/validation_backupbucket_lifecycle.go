@@ -0,0 +1,65 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+// ValidateBackupBucketLifecycle validates a BackupBucketLifecycle.
+func ValidateBackupBucketLifecycle(lifecycle *api.BackupBucketLifecycle) error {
+	if lifecycle == nil {
+		return nil
+	}
+
+	for i, rule := range lifecycle.Rules {
+		if rule.DeleteAfter != nil && rule.DeleteAfter.Duration <= 0 {
+			return fmt.Errorf("lifecycle rule %d (prefix %q): deleteAfter must be greater than zero", i, rule.Prefix)
+		}
+		if rule.TransitionToColdAfter != nil && rule.TransitionToColdAfter.Duration <= 0 {
+			return fmt.Errorf("lifecycle rule %d (prefix %q): transitionToColdAfter must be greater than zero", i, rule.Prefix)
+		}
+		if rule.DeleteAfter != nil && rule.TransitionToColdAfter != nil && rule.DeleteAfter.Duration <= rule.TransitionToColdAfter.Duration {
+			return fmt.Errorf("lifecycle rule %d (prefix %q): deleteAfter must be greater than transitionToColdAfter", i, rule.Prefix)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBackupBucketReplication validates a BackupBucketReplication.
+func ValidateBackupBucketReplication(replication *api.BackupBucketReplication) error {
+	if replication == nil {
+		return nil
+	}
+
+	seenRegions := map[string]bool{}
+	for i, target := range replication.Targets {
+		if target.Region == "" {
+			return fmt.Errorf("replication target %d: region must not be empty", i)
+		}
+		if target.SecretRef.Name == "" {
+			return fmt.Errorf("replication target %d (region %q): secretRef.name must not be empty", i, target.Region)
+		}
+		if seenRegions[target.Region] {
+			return fmt.Errorf("replication target %d: region %q is declared more than once", i, target.Region)
+		}
+		seenRegions[target.Region] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+func duration(d time.Duration) *metav1.Duration {
+	return &metav1.Duration{Duration: d}
+}
+
+var _ = Describe("ValidateBackupBucketLifecycle", func() {
+	DescribeTable("validating a BackupBucketLifecycle",
+		func(lifecycle *api.BackupBucketLifecycle, wantErr bool) {
+			err := ValidateBackupBucketLifecycle(lifecycle)
+			if wantErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("nil lifecycle is valid", (*api.BackupBucketLifecycle)(nil), false),
+		Entry("valid rule", &api.BackupBucketLifecycle{Rules: []api.BackupBucketLifecycleRule{
+			{Prefix: "etcd/", TransitionToColdAfter: duration(24 * time.Hour), DeleteAfter: duration(7 * 24 * time.Hour)},
+		}}, false),
+		Entry("zero deleteAfter", &api.BackupBucketLifecycle{Rules: []api.BackupBucketLifecycleRule{
+			{Prefix: "etcd/", DeleteAfter: duration(0)},
+		}}, true),
+		Entry("zero transitionToColdAfter", &api.BackupBucketLifecycle{Rules: []api.BackupBucketLifecycleRule{
+			{Prefix: "etcd/", TransitionToColdAfter: duration(0)},
+		}}, true),
+		Entry("deleteAfter not after transitionToColdAfter", &api.BackupBucketLifecycle{Rules: []api.BackupBucketLifecycleRule{
+			{Prefix: "etcd/", TransitionToColdAfter: duration(24 * time.Hour), DeleteAfter: duration(time.Hour)},
+		}}, true),
+	)
+})
+
+var _ = Describe("ValidateBackupBucketReplication", func() {
+	DescribeTable("validating a BackupBucketReplication",
+		func(replication *api.BackupBucketReplication, wantErr bool) {
+			err := ValidateBackupBucketReplication(replication)
+			if wantErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("nil replication is valid", (*api.BackupBucketReplication)(nil), false),
+		Entry("valid target", &api.BackupBucketReplication{Targets: []api.BackupBucketReplicationTarget{
+			{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "swift-sync-eu-de-2", Namespace: "garden"}},
+		}}, false),
+		Entry("empty region", &api.BackupBucketReplication{Targets: []api.BackupBucketReplicationTarget{
+			{Region: "", SecretRef: corev1.SecretReference{Name: "swift-sync"}},
+		}}, true),
+		Entry("empty secret name", &api.BackupBucketReplication{Targets: []api.BackupBucketReplicationTarget{
+			{Region: "eu-de-2", SecretRef: corev1.SecretReference{}},
+		}}, true),
+		Entry("duplicate region", &api.BackupBucketReplication{Targets: []api.BackupBucketReplicationTarget{
+			{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "a"}},
+			{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "b"}},
+		}}, true),
+	)
+})
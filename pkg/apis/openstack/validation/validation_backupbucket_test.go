@@ -0,0 +1,80 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+var _ = Describe("ValidateBackupBucketRetentionPolicy", func() {
+	DescribeTable("validating a BackupBucketRetentionPolicy",
+		func(policy *api.BackupBucketRetentionPolicy, wantErr bool) {
+			err := ValidateBackupBucketRetentionPolicy(policy)
+			if wantErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("nil policy is valid", (*api.BackupBucketRetentionPolicy)(nil), false),
+		Entry("valid governance policy", &api.BackupBucketRetentionPolicy{
+			Mode:     api.BackupBucketRetentionGovernance,
+			Duration: metav1.Duration{Duration: 24 * time.Hour},
+		}, false),
+		Entry("invalid mode", &api.BackupBucketRetentionPolicy{
+			Mode:     "bogus",
+			Duration: metav1.Duration{Duration: 24 * time.Hour},
+		}, true),
+		Entry("zero duration", &api.BackupBucketRetentionPolicy{
+			Mode:     api.BackupBucketRetentionCompliance,
+			Duration: metav1.Duration{Duration: 0},
+		}, true),
+		Entry("negative duration", &api.BackupBucketRetentionPolicy{
+			Mode:     api.BackupBucketRetentionCompliance,
+			Duration: metav1.Duration{Duration: -time.Hour},
+		}, true),
+	)
+
+	Describe("ValidateBackupBucketRetentionPolicyUpdate", func() {
+		var (
+			governance1h   = &api.BackupBucketRetentionPolicy{Mode: api.BackupBucketRetentionGovernance, Duration: metav1.Duration{Duration: time.Hour}}
+			compliance1h   = &api.BackupBucketRetentionPolicy{Mode: api.BackupBucketRetentionCompliance, Duration: metav1.Duration{Duration: time.Hour}}
+			compliance2h   = &api.BackupBucketRetentionPolicy{Mode: api.BackupBucketRetentionCompliance, Duration: metav1.Duration{Duration: 2 * time.Hour}}
+			governance30m  = &api.BackupBucketRetentionPolicy{Mode: api.BackupBucketRetentionGovernance, Duration: metav1.Duration{Duration: 30 * time.Minute}}
+		)
+
+		DescribeTable("validating an update to a BackupBucketRetentionPolicy",
+			func(oldPolicy, newPolicy *api.BackupBucketRetentionPolicy, wantErr bool) {
+				err := ValidateBackupBucketRetentionPolicyUpdate(oldPolicy, newPolicy)
+				if wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("no prior policy allows any new policy", (*api.BackupBucketRetentionPolicy)(nil), compliance1h, false),
+			Entry("extending compliance duration is allowed", compliance1h, compliance2h, false),
+			Entry("removing compliance mode is forbidden", compliance1h, (*api.BackupBucketRetentionPolicy)(nil), true),
+			Entry("downgrading from compliance to governance is forbidden", compliance1h, governance1h, true),
+			Entry("shortening governance duration is forbidden", governance1h, governance30m, true),
+		)
+	})
+})
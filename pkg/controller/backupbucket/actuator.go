@@ -0,0 +1,116 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backupbucket contains the actuator that provisions the Swift/S3 object-store container backing a
+// BackupBucket.
+package backupbucket
+
+import (
+	"context"
+	"fmt"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+	bbinternal "github.com/gardener/gardener-extension-provider-openstack/pkg/internal/backupbucket"
+)
+
+// generatedSecretName is the name of the Secret holding the backend-scoped credentials minted for a BackupBucket,
+// referenced by bb.Status.GeneratedSecretRef.
+func generatedSecretName(bb *extensionsv1alpha1.BackupBucket) string {
+	return fmt.Sprintf("generated-bucket-%s", bb.Name)
+}
+
+// actuator reconciles and deletes the object-store container backing a BackupBucket.
+type actuator struct {
+	client    client.Client
+	newClient func(ctx context.Context, bb *extensionsv1alpha1.BackupBucket) (bbinternal.Client, error)
+}
+
+// NewActuator creates a new backupbucket.Actuator. c is used to persist the generated credentials Secret.
+// newClient is used to create the object-store client for the credentials referenced by the BackupBucket's
+// cloudprovider secret.
+func NewActuator(c client.Client, newClient func(ctx context.Context, bb *extensionsv1alpha1.BackupBucket) (bbinternal.Client, error)) *actuator {
+	return &actuator{client: c, newClient: newClient}
+}
+
+// Reconcile ensures the bucket's container exists, carries the retention policy declared in config (rejecting
+// any attempt to shorten or remove a previously applied policy), and is replicated to every target declared in
+// config.Replication. It selects the Backend implementation from config.Provider.Backend and persists the
+// backend-generated credentials to a Secret referenced by bb.Status.GeneratedSecretRef. config and oldStatus are
+// assumed already decoded from bb.Spec.ProviderConfig and bb.Status.ProviderStatus respectively. The returned
+// status should be persisted back to bb.Status.ProviderStatus by the caller.
+func (a *actuator) Reconcile(ctx context.Context, bb *extensionsv1alpha1.BackupBucket, config *api.BackupBucketConfig, oldStatus *apiv1alpha1.BackupBucketStatus) (*apiv1alpha1.BackupBucketStatus, error) {
+	objClient, err := a.newClient(ctx, bb)
+	if err != nil {
+		return nil, fmt.Errorf("could not create object-store client: %w", err)
+	}
+
+	backend, err := bbinternal.SelectBackend(config.Provider, objClient)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := backend.Ensure(ctx, bb.Name, config, oldStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	secretData, err := backend.GenerateSecret(ctx, bb.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	secretRef, err := a.persistGeneratedSecret(ctx, bb, secretData)
+	if err != nil {
+		return nil, err
+	}
+	bb.Status.GeneratedSecretRef = secretRef
+
+	return status, nil
+}
+
+// persistGeneratedSecret creates or updates the Secret holding data in bb's namespace and returns a reference
+// to it.
+func (a *actuator) persistGeneratedSecret(ctx context.Context, bb *extensionsv1alpha1.BackupBucket, data map[string][]byte) (*corev1.SecretReference, error) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: generatedSecretName(bb), Namespace: bb.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.client, secret, func() error {
+		secret.Data = data
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("could not persist generated credentials secret: %w", err)
+	}
+
+	return &corev1.SecretReference{Name: secret.Name, Namespace: secret.Namespace}, nil
+}
+
+// Delete tears down the container backing bb via the backend selected by config.Provider.Backend.
+func (a *actuator) Delete(ctx context.Context, bb *extensionsv1alpha1.BackupBucket, config *api.BackupBucketConfig) error {
+	objClient, err := a.newClient(ctx, bb)
+	if err != nil {
+		return fmt.Errorf("could not create object-store client: %w", err)
+	}
+
+	backend, err := bbinternal.SelectBackend(config.Provider, objClient)
+	if err != nil {
+		return err
+	}
+
+	return backend.Delete(ctx, bb.Name)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionscontrolplane "github.com/gardener/gardener/extensions/pkg/controller/controlplane"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// actuator wraps the generic, chart-value-driven controlplane.Actuator produced from a valuesProvider (see
+// genericactuator.NewActuator), additionally tearing down any managed application credential on Delete so that
+// a credential created via ManageApplicationCredentialsAnnotation does not outlive the ControlPlane resource
+// that created it.
+type actuator struct {
+	extensionscontrolplane.Actuator
+	vp *valuesProvider
+}
+
+// NewActuator wraps generic, the controlplane.Actuator built from vp (e.g. via genericactuator.NewActuator),
+// adding application credential cleanup on Delete. vp must be the same valuesProvider instance generic was built
+// from.
+func NewActuator(generic extensionscontrolplane.Actuator, vp *valuesProvider) extensionscontrolplane.Actuator {
+	return &actuator{Actuator: generic, vp: vp}
+}
+
+// Delete deletes the rendered control plane charts via the wrapped generic actuator, and then, if the
+// cloudprovider secret opted into managed application credentials, deletes the managed credential and its
+// state ConfigMap so neither outlives the ControlPlane.
+func (a *actuator) Delete(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) error {
+	if err := a.Actuator.Delete(ctx, cp, cluster); err != nil {
+		return err
+	}
+
+	if a.vp.appCredClient == nil {
+		return nil
+	}
+
+	creds, err := getCredentials(ctx, a.vp.client, cp.Namespace)
+	if err != nil {
+		return err
+	}
+	if !creds.manageAppCredentials || creds.username == "" {
+		return nil
+	}
+
+	if err := DeleteManagedApplicationCredential(ctx, a.vp.client, a.vp.appCredClient, cp.Namespace, creds.username); err != nil {
+		return fmt.Errorf("could not delete managed application credential: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/internal/openstack/redact"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+// ManageApplicationCredentialsAnnotation, when set to "true" on the cloudprovider secret, opts the shoot into
+// automatic application-credential rotation: instead of authenticating with the secret's static
+// username/password directly, the control plane creates and rotates a scoped Keystone application credential
+// on the user's behalf and patches the resulting ID/secret back into the cloudprovider secret.
+const ManageApplicationCredentialsAnnotation = "openstack.provider.extensions.gardener.cloud/manage-app-credentials"
+
+// DefaultApplicationCredentialRotationPeriod is used when ControlPlaneConfig.ApplicationCredentialRotation is
+// nil or does not specify a RotationPeriod.
+const DefaultApplicationCredentialRotationPeriod = 90 * 24 * time.Hour
+
+// ApplicationCredentialClient is the minimal set of Keystone operations needed to manage a scoped application
+// credential on behalf of the shoot's OpenStack user. It is satisfied by a thin wrapper around gophercloud's
+// identity/v3/applicationcredentials service, which keeps this package testable without a real Keystone.
+type ApplicationCredentialClient interface {
+	CreateApplicationCredential(ctx context.Context, username, name string) (id, secret string, err error)
+	DeleteApplicationCredential(ctx context.Context, username, id string) error
+}
+
+// applicationCredentialState is the state this package persists across reconciliations in the
+// openstack.ApplicationCredentialStateConfigMapName ConfigMap. Keeping PreviousCredentialID around until the
+// old credential has actually been deleted in Keystone is what makes rotation safe to resume after a crash:
+// on the next reconciliation, CredentialID is already the one patched into the secret, so only the cleanup of
+// PreviousCredentialID needs to be retried.
+type applicationCredentialState struct {
+	CredentialID         string    `json:"credentialID"`
+	CredentialName       string    `json:"credentialName"`
+	CreatedAt            time.Time `json:"createdAt"`
+	PreviousCredentialID string    `json:"previousCredentialID,omitempty"`
+}
+
+// ensureManagedApplicationCredential rotates a Keystone application credential on behalf of the shoot's
+// OpenStack user when the cloudprovider secret opts in via ManageApplicationCredentialsAnnotation and carries
+// only a static username/password. When management kicks in, creds.applicationCredentialID and
+// creds.applicationCredentialSecret are overwritten in place with the managed credential, mirroring the other
+// credential-shaping overrides in this package (see credentials.workloadIdentity).
+func ensureManagedApplicationCredential(
+	ctx context.Context,
+	c client.Client,
+	appCredClient ApplicationCredentialClient,
+	namespace string,
+	creds *credentials,
+	rotation *api.ApplicationCredentialRotation,
+) error {
+	if !creds.manageAppCredentials || creds.username == "" || creds.password == "" {
+		return nil
+	}
+	if appCredClient == nil {
+		return fmt.Errorf("%s=true but no ApplicationCredentialClient is configured", ManageApplicationCredentialsAnnotation)
+	}
+
+	rotationPeriod := DefaultApplicationCredentialRotationPeriod
+	if rotation != nil && rotation.RotationPeriod != nil {
+		rotationPeriod = rotation.RotationPeriod.Duration
+	}
+
+	state, err := getApplicationCredentialState(ctx, c, namespace)
+	if err != nil {
+		return err
+	}
+
+	// secretLive reports whether the cloudprovider secret has already been observed carrying the credential
+	// this package last created, i.e. whether the patch from a previous reconciliation has taken effect.
+	secretLive := state.CredentialID != "" && state.CredentialID == creds.applicationCredentialID
+
+	created := false
+	if !secretLive || time.Since(state.CreatedAt) >= rotationPeriod {
+		name := fmt.Sprintf("gardener-%s-%d", namespace, time.Now().Unix())
+		newID, newSecret, err := appCredClient.CreateApplicationCredential(ctx, creds.username, name)
+		if err != nil {
+			logger.Error(err, "could not create application credential", "namespace", namespace,
+				"credentials", redact.Redact(map[string]interface{}{"username": creds.username, "name": name}))
+			return fmt.Errorf("could not create application credential: %w", err)
+		}
+
+		if err := patchCloudProviderApplicationCredential(ctx, c, namespace, newID, newSecret); err != nil {
+			return err
+		}
+
+		state = &applicationCredentialState{
+			CredentialID:         newID,
+			CredentialName:       name,
+			CreatedAt:            time.Now(),
+			PreviousCredentialID: state.CredentialID,
+		}
+		if err := saveApplicationCredentialState(ctx, c, namespace, state); err != nil {
+			return err
+		}
+
+		creds.applicationCredentialID = newID
+		creds.applicationCredentialSecret = newSecret
+		created = true
+	} else {
+		creds.applicationCredentialID = state.CredentialID
+	}
+
+	// The previous credential is only deleted once the new one was observed live on a prior reconciliation
+	// (i.e. not in the same call that just created it), giving the CCM/CSI restart triggered by the secret's
+	// changed checksum annotations time to actually roll out onto the new credential first.
+	if !created && state.PreviousCredentialID != "" {
+		if err := appCredClient.DeleteApplicationCredential(ctx, creds.username, state.PreviousCredentialID); err != nil {
+			return fmt.Errorf("could not delete previous application credential %q: %w", state.PreviousCredentialID, err)
+		}
+		state.PreviousCredentialID = ""
+		if err := saveApplicationCredentialState(ctx, c, namespace, state); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteManagedApplicationCredential tears down a managed application credential and its state ConfigMap. It
+// is meant to be called from the controlplane actuator's Delete reconciliation, alongside the deletion of the
+// rendered charts, so that a managed application credential does not outlive the ControlPlane resource that
+// created it. It is a no-op if no credential was ever created.
+func DeleteManagedApplicationCredential(ctx context.Context, c client.Client, appCredClient ApplicationCredentialClient, namespace, username string) error {
+	state, err := getApplicationCredentialState(ctx, c, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range []string{state.CredentialID, state.PreviousCredentialID} {
+		if id == "" {
+			continue
+		}
+		if err := appCredClient.DeleteApplicationCredential(ctx, username, id); err != nil {
+			return fmt.Errorf("could not delete application credential %q: %w", id, err)
+		}
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: openstack.ApplicationCredentialStateConfigMapName, Namespace: namespace}}
+	if err := c.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("could not delete application credential state configmap: %w", err)
+	}
+
+	return nil
+}
+
+func getApplicationCredentialState(ctx context.Context, c client.Client, namespace string) (*applicationCredentialState, error) {
+	configMap := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: openstack.ApplicationCredentialStateConfigMapName}, configMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return &applicationCredentialState{}, nil
+		}
+		return nil, fmt.Errorf("could not get application credential state configmap: %w", err)
+	}
+
+	state := &applicationCredentialState{}
+	if raw, ok := configMap.Data[openstack.ApplicationCredentialStateDataKey]; ok {
+		if err := json.Unmarshal([]byte(raw), state); err != nil {
+			return nil, fmt.Errorf("could not decode application credential state: %w", err)
+		}
+	}
+	return state, nil
+}
+
+func saveApplicationCredentialState(ctx context.Context, c client.Client, namespace string, state *applicationCredentialState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not encode application credential state: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	getErr := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: openstack.ApplicationCredentialStateConfigMapName}, configMap)
+	switch {
+	case apierrors.IsNotFound(getErr):
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: openstack.ApplicationCredentialStateConfigMapName, Namespace: namespace},
+			Data:       map[string]string{openstack.ApplicationCredentialStateDataKey: string(raw)},
+		}
+		if err := c.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("could not create application credential state configmap: %w", err)
+		}
+	case getErr != nil:
+		return fmt.Errorf("could not get application credential state configmap: %w", getErr)
+	default:
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[openstack.ApplicationCredentialStateDataKey] = string(raw)
+		if err := c.Update(ctx, configMap); err != nil {
+			return fmt.Errorf("could not update application credential state configmap: %w", err)
+		}
+	}
+	return nil
+}
+
+// patchCloudProviderApplicationCredential patches the cloudprovider secret's applicationCredentialID/Secret
+// fields, which in turn changes the secret's content checksum and triggers a CCM/CSI restart through the
+// existing checksum-annotation mechanism used by GetControlPlaneChartValues/GetControlPlaneShootChartValues.
+func patchCloudProviderApplicationCredential(ctx context.Context, c client.Client, namespace, applicationCredentialID, applicationCredentialSecret string) error {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}, secret); err != nil {
+		return fmt.Errorf("could not get cloudprovider secret: %w", err)
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data["applicationCredentialID"] = []byte(applicationCredentialID)
+	secret.Data["applicationCredentialSecret"] = []byte(applicationCredentialSecret)
+
+	if err := c.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("could not patch cloudprovider secret with application credential: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+// fakeApplicationCredentialClient is an in-memory stand-in for a gophercloud-backed Keystone client, recording
+// every create/delete so tests can assert on rotation/cleanup behavior without a live Keystone.
+type fakeApplicationCredentialClient struct {
+	created   []string
+	deleted   []string
+	nextID    int
+	createErr error
+	deleteErr error
+}
+
+func (f *fakeApplicationCredentialClient) CreateApplicationCredential(_ context.Context, _, name string) (string, string, error) {
+	if f.createErr != nil {
+		return "", "", f.createErr
+	}
+	f.nextID++
+	id := fmt.Sprintf("cred-%d", f.nextID)
+	f.created = append(f.created, id)
+	return id, id + "-secret", nil
+}
+
+func (f *fakeApplicationCredentialClient) DeleteApplicationCredential(_ context.Context, _, id string) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+var _ = Describe("ensureManagedApplicationCredential / DeleteManagedApplicationCredential", func() {
+	var (
+		fakeClient client.Client
+		appCred    *fakeApplicationCredentialClient
+		creds      *credentials
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		appCred = &fakeApplicationCredentialClient{}
+		creds = &credentials{
+			username:             "user",
+			password:             "pw",
+			manageAppCredentials: true,
+		}
+
+		cloudProviderSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.SecretNameCloudProvider, Namespace: namespace},
+			Data:       map[string][]byte{},
+		}
+		Expect(fakeClient.Create(context.Background(), cloudProviderSecret)).To(Succeed())
+	})
+
+	It("creates a managed application credential on first reconciliation and patches the cloudprovider secret", func() {
+		Expect(ensureManagedApplicationCredential(context.Background(), fakeClient, appCred, namespace, creds, nil)).To(Succeed())
+
+		Expect(appCred.created).To(HaveLen(1))
+		Expect(creds.applicationCredentialID).To(Equal(appCred.created[0]))
+
+		secret := &corev1.Secret{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}, secret)).To(Succeed())
+		Expect(string(secret.Data["applicationCredentialID"])).To(Equal(appCred.created[0]))
+
+		state, err := getApplicationCredentialState(context.Background(), fakeClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.CredentialID).To(Equal(appCred.created[0]))
+		Expect(state.PreviousCredentialID).To(BeEmpty())
+	})
+
+	It("recovers from a crash mid-rotation where both the previous and new credential are still present", func() {
+		// Simulate a prior reconciliation that created the new credential, patched the secret, and persisted
+		// the state, but crashed before it could delete the previous credential.
+		creds.applicationCredentialID = "cred-new"
+		Expect(saveApplicationCredentialState(context.Background(), fakeClient, namespace, &applicationCredentialState{
+			CredentialID:         "cred-new",
+			CredentialName:       "gardener-test-new",
+			PreviousCredentialID: "cred-old",
+		})).To(Succeed())
+
+		Expect(ensureManagedApplicationCredential(context.Background(), fakeClient, appCred, namespace, creds, nil)).To(Succeed())
+
+		Expect(appCred.created).To(BeEmpty(), "the secret already carries the live credential, so no new one should be minted")
+		Expect(appCred.deleted).To(Equal([]string{"cred-old"}), "the stale previous credential must be cleaned up once the new one is confirmed live")
+
+		state, err := getApplicationCredentialState(context.Background(), fakeClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.CredentialID).To(Equal("cred-new"))
+		Expect(state.PreviousCredentialID).To(BeEmpty())
+	})
+
+	It("deletes every managed credential and the state configmap on ControlPlane deletion", func() {
+		Expect(saveApplicationCredentialState(context.Background(), fakeClient, namespace, &applicationCredentialState{
+			CredentialID:         "cred-new",
+			PreviousCredentialID: "cred-old",
+		})).To(Succeed())
+
+		Expect(DeleteManagedApplicationCredential(context.Background(), fakeClient, appCred, namespace, "user")).To(Succeed())
+
+		Expect(appCred.deleted).To(ConsistOf("cred-new", "cred-old"))
+
+		configMap := &corev1.ConfigMap{}
+		err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: openstack.ApplicationCredentialStateConfigMapName}, configMap)
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		Expect(err).To(HaveOccurred(), "the state configmap must be removed so a future create starts from a clean slate")
+	})
+
+	It("is a no-op on deletion when no credential was ever created", func() {
+		Expect(DeleteManagedApplicationCredential(context.Background(), fakeClient, appCred, namespace, "user")).To(Succeed())
+		Expect(appCred.deleted).To(BeEmpty())
+	})
+})
@@ -0,0 +1,665 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package controlplane contains the ValuesProvider implementation that renders the charts deployed into the
+// shoot's control plane namespace (cloud-controller-manager, CSI drivers) and into the shoot itself.
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	calicov1alpha1 "github.com/gardener/gardener-extension-networking-calico/pkg/apis/calico/v1alpha1"
+	"github.com/gardener/gardener-extension-networking-calico/pkg/calico"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/gardener/gardener/extensions/pkg/controller/controlplane/genericactuator"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/internal/openstack/redact"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+// logger logs diagnostics for this package's chart-value rendering. Chart values are only ever logged through
+// redact.Redact, since they carry credential material (passwords, application credential secrets, workload
+// identity tokens) that must not end up in klog output or Events.
+var logger = logf.Log.WithName("controlplane-valuesprovider")
+
+// ServiceAccountTokenAudienceDefault is used when neither the CloudProfile nor the cloudprovider secret specify
+// a token audience for workload identity federation.
+const ServiceAccountTokenAudienceDefault = "openstack.provider.extensions.gardener.cloud"
+
+// ServiceAccountTokenFilePathDefault is used when neither the CloudProfile nor the cloudprovider secret specify
+// a mount path for the projected service account token used for workload identity federation.
+const ServiceAccountTokenFilePathDefault = "/var/run/secrets/gardener.cloud/workload-identity/token"
+
+// valuesProvider renders the OpenStack specific chart values for a Shoot's control plane.
+type valuesProvider struct {
+	client        client.Client
+	scheme        *runtime.Scheme
+	appCredClient ApplicationCredentialClient
+}
+
+// NewValuesProvider creates a new genericactuator.ValuesProvider for the OpenStack provider.
+func NewValuesProvider() genericactuator.ValuesProvider {
+	return &valuesProvider{}
+}
+
+// NewValuesProviderWithApplicationCredentialClient creates a new genericactuator.ValuesProvider for the
+// OpenStack provider that additionally manages and rotates Keystone application credentials on behalf of
+// shoots that opt in via ManageApplicationCredentialsAnnotation.
+func NewValuesProviderWithApplicationCredentialClient(appCredClient ApplicationCredentialClient) genericactuator.ValuesProvider {
+	return &valuesProvider{appCredClient: appCredClient}
+}
+
+// InjectScheme injects the given scheme into the valuesProvider.
+func (vp *valuesProvider) InjectScheme(scheme *runtime.Scheme) error {
+	vp.scheme = scheme
+	return nil
+}
+
+// InjectClient injects the given client into the valuesProvider.
+func (vp *valuesProvider) InjectClient(c client.Client) error {
+	vp.client = c
+	return nil
+}
+
+// credentials is the set of fields the extension recognizes in the `cloudprovider` secret.
+type credentials struct {
+	domainName                  string
+	tenantName                  string
+	username                    string
+	password                    string
+	applicationCredentialID     string
+	applicationCredentialName   string
+	applicationCredentialSecret string
+	authURL                     string
+	caCert                      string
+
+	// manageAppCredentials reports whether the cloudprovider secret opted into automatic application-credential
+	// management via ManageApplicationCredentialsAnnotation.
+	manageAppCredentials bool
+
+	// Workload identity / Keystone OIDC federation fields. Populated instead of username/password or
+	// application-credential fields when the shoot authenticates via a projected service account token.
+	identityProviderID string
+	protocol           string
+	clientID           string
+	tokenAudience      string
+	tokenFilePath      string
+}
+
+// workloadIdentity reports whether the credentials describe a federated-identity shoot, i.e. one with no
+// static secret material at all.
+func (c credentials) workloadIdentity() bool {
+	return c.identityProviderID != "" && c.username == "" && c.password == "" && c.applicationCredentialID == ""
+}
+
+func getCredentials(ctx context.Context, c client.Client, namespace string) (*credentials, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}, secret); err != nil {
+		return nil, fmt.Errorf("could not get cloudprovider secret: %w", err)
+	}
+
+	get := func(key string) string {
+		if v, ok := secret.Data[key]; ok {
+			return string(v)
+		}
+		return ""
+	}
+
+	return &credentials{
+		domainName:                  get("domainName"),
+		tenantName:                  get("tenantName"),
+		username:                    get("username"),
+		password:                    get("password"),
+		applicationCredentialID:     get("applicationCredentialID"),
+		applicationCredentialName:   get("applicationCredentialName"),
+		applicationCredentialSecret: get("applicationCredentialSecret"),
+		authURL:                     get("authURL"),
+		caCert:                      get("caCert"),
+		identityProviderID:          get("identityProviderID"),
+		protocol:                    get("protocol"),
+		clientID:                    get("clientID"),
+		tokenAudience:               get("tokenAudience"),
+		tokenFilePath:               get("tokenFilePath"),
+		manageAppCredentials:        secret.Annotations[ManageApplicationCredentialsAnnotation] == "true",
+	}, nil
+}
+
+// resolveWorkloadIdentityDefaults fills in creds.tokenAudience/tokenFilePath from the CloudProfile's
+// WorkloadIdentity defaults, falling back to the package-level constants, whenever the cloudprovider secret
+// did not specify its own values. It is a no-op for credentials that do not describe a federated-identity
+// shoot.
+func resolveWorkloadIdentityDefaults(creds *credentials, cloudProfileConfig *api.CloudProfileConfig) {
+	if !creds.workloadIdentity() {
+		return
+	}
+
+	if creds.tokenAudience == "" {
+		if cloudProfileConfig.WorkloadIdentity != nil && cloudProfileConfig.WorkloadIdentity.TokenAudience != "" {
+			creds.tokenAudience = cloudProfileConfig.WorkloadIdentity.TokenAudience
+		} else {
+			creds.tokenAudience = ServiceAccountTokenAudienceDefault
+		}
+	}
+
+	if creds.tokenFilePath == "" {
+		if cloudProfileConfig.WorkloadIdentity != nil && cloudProfileConfig.WorkloadIdentity.TokenFilePath != "" {
+			creds.tokenFilePath = cloudProfileConfig.WorkloadIdentity.TokenFilePath
+		} else {
+			creds.tokenFilePath = ServiceAccountTokenFilePathDefault
+		}
+	}
+}
+
+// workloadIdentityChartValues renders the OIDC federation fields shared by the CCM, cinder-csi, manila-csi and
+// machine-controller-manager charts, consumed next to the projected ServiceAccountToken volume mount that
+// those charts configure using tokenFilePath.
+func workloadIdentityChartValues(creds *credentials) map[string]interface{} {
+	return map[string]interface{}{
+		"identityProviderID": creds.identityProviderID,
+		"protocol":           creds.protocol,
+		"clientID":           creds.clientID,
+		"tokenAudience":      creds.tokenAudience,
+		"tokenFilePath":      creds.tokenFilePath,
+	}
+}
+
+// getTrustedCABundle reads the operator-maintained, cluster-wide trusted CA bundle ConfigMap, the
+// shoot-specific CA bundle ConfigMap referenced by ControlPlaneConfig.CACertificateConfigMapRef (if any), and
+// merges them with the shoot-specific caCert from the cloudprovider secret. Both ConfigMaps are optional: if
+// neither exists, only the secret's caCert (if any) is returned. The operator-maintained bundle is always the
+// prefix of the result, followed by the shoot-referenced bundle and finally the secret's caCert, so that
+// shoot-specific certificates are appended after (and thus take precedence in parsers that use the last
+// matching entry).
+func getTrustedCABundle(ctx context.Context, c client.Client, namespace string, secretCACert string, cpConfig *api.ControlPlaneConfig) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: openstack.TrustedCABundleConfigMapName}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("could not get trusted CA bundle configmap: %w", err)
+	}
+
+	var shootBundle string
+	if cpConfig.CACertificateConfigMapRef != nil {
+		shootConfigMap := &corev1.ConfigMap{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: *cpConfig.CACertificateConfigMapRef}, shootConfigMap); err != nil {
+			return "", fmt.Errorf("could not get shoot CA certificate configmap %q: %w", *cpConfig.CACertificateConfigMapRef, err)
+		}
+		shootBundle = shootConfigMap.Data[openstack.ShootCACertificateDataKey]
+	}
+
+	var parts []string
+	for _, part := range []string{configMap.Data[openstack.TrustedCABundleDataKey], shootBundle, secretCACert} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// GetConfigChartValues returns the values for the cloud-provider-config chart applied to the Seed.
+func (vp *valuesProvider) GetConfigChartValues(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	cpConfig := &api.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if err := json.Unmarshal(cp.Spec.ProviderConfig.Raw, cpConfig); err != nil {
+			return nil, fmt.Errorf("could not decode provider config: %w", err)
+		}
+	}
+
+	infraStatus := &api.InfrastructureStatus{}
+	if cp.Spec.InfrastructureProviderStatus != nil {
+		if err := json.Unmarshal(cp.Spec.InfrastructureProviderStatus.Raw, infraStatus); err != nil {
+			return nil, fmt.Errorf("could not decode infrastructure status: %w", err)
+		}
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := getCredentials(ctx, vp.client, cp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureManagedApplicationCredential(ctx, vp.client, vp.appCredClient, cp.Namespace, creds, cpConfig.ApplicationCredentialRotation); err != nil {
+		return nil, err
+	}
+
+	authURL, err := helper.FindKeyStoneURL(cloudProfileConfig.KeyStoneURLs, cloudProfileConfig.KeyStoneURL, cp.Spec.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	var subnetID string
+	for _, subnet := range infraStatus.Networks.Subnets {
+		if subnet.Purpose == api.PurposeNodes {
+			subnetID = subnet.ID
+			break
+		}
+	}
+
+	values := map[string]interface{}{
+		"domainName":                  creds.domainName,
+		"tenantName":                  creds.tenantName,
+		"username":                    creds.username,
+		"password":                    creds.password,
+		"region":                      cp.Spec.Region,
+		"subnetID":                    subnetID,
+		"lbProvider":                  cpConfig.LoadBalancerProvider,
+		"floatingNetworkID":           infraStatus.Networks.FloatingPool.ID,
+		"insecure":                    cloudProfileConfig.KeyStoneForceInsecure,
+		"authUrl":                     authURL,
+		"dhcpDomain":                  cloudProfileConfig.DHCPDomain,
+		"requestTimeout":              cloudProfileConfig.RequestTimeout,
+		"useOctavia":                  boolValue(cloudProfileConfig.UseOctavia),
+		"rescanBlockStorageOnResize":  boolValue(cloudProfileConfig.RescanBlockStorageOnResize),
+		"ignoreVolumeAZ":              boolValue(cloudProfileConfig.IgnoreVolumeAZ),
+		"nodeVolumeAttachLimit":       cloudProfileConfig.NodeVolumeAttachLimit,
+		"applicationCredentialID":     creds.applicationCredentialID,
+		"applicationCredentialSecret": creds.applicationCredentialSecret,
+		"applicationCredentialName":   creds.applicationCredentialName,
+		"internalNetworkName":         infraStatus.Networks.Name,
+	}
+
+	if cpConfig.Storage != nil {
+		if cpConfig.Storage.IgnoreVolumeAZ != nil {
+			values["ignoreVolumeAZ"] = *cpConfig.Storage.IgnoreVolumeAZ
+		}
+		if cpConfig.Storage.BSVersion != nil {
+			values["bsVersion"] = *cpConfig.Storage.BSVersion
+		}
+	}
+
+	if creds.workloadIdentity() {
+		resolveWorkloadIdentityDefaults(creds, cloudProfileConfig)
+
+		values["username"] = ""
+		values["password"] = ""
+		values["applicationCredentialID"] = ""
+		values["applicationCredentialSecret"] = ""
+		values["applicationCredentialName"] = ""
+		values["tokenAudience"] = creds.tokenAudience
+		values["tokenFilePath"] = creds.tokenFilePath
+		values["identityProviderID"] = creds.identityProviderID
+		values["protocol"] = creds.protocol
+		values["clientID"] = creds.clientID
+	}
+
+	if creds.caCert != "" {
+		values["caCert"] = creds.caCert
+	}
+
+	caBundle, err := getTrustedCABundle(ctx, vp.client, cp.Namespace, creds.caCert, cpConfig)
+	if err != nil {
+		return nil, err
+	}
+	if caBundle != "" {
+		values["caBundle"] = caBundle
+	}
+
+	if floatingSubnetID := defaultFloatingSubnetID(cpConfig.LoadBalancerClasses); floatingSubnetID != nil {
+		values["floatingSubnetID"] = *floatingSubnetID
+	}
+
+	if len(cpConfig.LoadBalancerClasses) > 0 {
+		values["floatingClasses"] = floatingClassesChartValues(cpConfig.LoadBalancerClasses)
+	}
+
+	if !usesOverlayNetwork(cluster) {
+		values["routerID"] = infraStatus.Networks.Router.ID
+	}
+
+	logger.V(5).Info("rendered config chart values", "namespace", cp.Namespace, "values", redact.Redact(values))
+
+	return values, nil
+}
+
+// boolValue dereferences an optional bool pointer, defaulting to false when unset.
+func boolValue(b *bool) bool {
+	if b == nil {
+		return false
+	}
+	return *b
+}
+
+// defaultFloatingSubnetID finds the LoadBalancerClass that should drive the top-level floatingSubnetID value:
+// the class explicitly marked with Purpose "default", or else the class named "default".
+func defaultFloatingSubnetID(classes []api.LoadBalancerClass) *string {
+	var byName *api.LoadBalancerClass
+	for i, class := range classes {
+		if class.Purpose != nil && *class.Purpose == "default" {
+			return classes[i].FloatingSubnetID
+		}
+		if class.Name == "default" {
+			byName = &classes[i]
+		}
+	}
+	if byName != nil {
+		return byName.FloatingSubnetID
+	}
+	return nil
+}
+
+func floatingClassesChartValues(classes []api.LoadBalancerClass) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(classes))
+	for _, class := range classes {
+		value := map[string]interface{}{"name": class.Name}
+		if class.FloatingNetworkID != nil {
+			value["floatingNetworkID"] = *class.FloatingNetworkID
+		}
+		if class.FloatingSubnetID != nil {
+			value["floatingSubnetID"] = *class.FloatingSubnetID
+		}
+		if class.FloatingSubnetName != nil {
+			value["floatingSubnetName"] = *class.FloatingSubnetName
+		}
+		if class.FloatingSubnetTags != nil {
+			value["floatingSubnetTags"] = *class.FloatingSubnetTags
+		}
+		if class.SubnetID != nil {
+			value["subnetID"] = *class.SubnetID
+		}
+		result = append(result, value)
+	}
+	return result
+}
+
+// usesOverlayNetwork reports whether the Shoot's network plugin runs an overlay network. When it does not
+// (e.g. Calico with Overlay.Enabled=false), the cloud-controller-manager needs the router ID to configure
+// cloud routes.
+func usesOverlayNetwork(cluster *extensionscontroller.Cluster) bool {
+	networking := cluster.Shoot.Spec.Networking
+	if networking.Type != calico.ReleaseName || networking.ProviderConfig == nil || networking.ProviderConfig.Object == nil {
+		return true
+	}
+
+	networkConfig, ok := networking.ProviderConfig.Object.(*calicov1alpha1.NetworkConfig)
+	if !ok || networkConfig.Overlay == nil {
+		return true
+	}
+
+	return networkConfig.Overlay.Enabled
+}
+
+// GetControlPlaneChartValues returns the values for the control plane chart applied to the Seed.
+func (vp *valuesProvider) GetControlPlaneChartValues(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	secretsManager secretsmanager.Interface,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	cpConfig := &api.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if err := json.Unmarshal(cp.Spec.ProviderConfig.Raw, cpConfig); err != nil {
+			return nil, fmt.Errorf("could not decode provider config: %w", err)
+		}
+	}
+
+	creds, err := getCredentials(ctx, vp.client, cp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	caBundle, err := getTrustedCABundle(ctx, vp.client, cp.Namespace, creds.caCert, cpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// caBundle, when set, is mounted into the cloud-controller-manager and CSI controller Deployments
+	// alongside a corresponding SSL_CERT_FILE environment variable, so that TLS verification against
+	// Keystone/Nova/Cinder succeeds against a private PKI.
+	values := map[string]interface{}{}
+	if caBundle != "" {
+		values["caBundle"] = caBundle
+	}
+
+	if creds.workloadIdentity() {
+		cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+		if err != nil {
+			return nil, err
+		}
+		resolveWorkloadIdentityDefaults(creds, cloudProfileConfig)
+		values["workloadIdentity"] = workloadIdentityChartValues(creds)
+	}
+
+	if shareTypes := manilaShareTypes(cpConfig); len(shareTypes) > 0 {
+		values["csi-manila-storage-classes"] = manilaNodePublishSecretsChartValues(shareTypes)
+	}
+
+	logger.V(5).Info("rendered control plane chart values", "namespace", cp.Namespace, "values", redact.Redact(values))
+
+	return values, nil
+}
+
+// GetControlPlaneShootChartValues returns the values for the control plane chart applied to the Shoot.
+func (vp *valuesProvider) GetControlPlaneShootChartValues(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	secretsManager secretsmanager.Interface,
+	checksums map[string]string,
+) (map[string]interface{}, error) {
+	cpConfig := &api.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if err := json.Unmarshal(cp.Spec.ProviderConfig.Raw, cpConfig); err != nil {
+			return nil, fmt.Errorf("could not decode provider config: %w", err)
+		}
+	}
+
+	creds, err := getCredentials(ctx, vp.client, cp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	caBundle, err := getTrustedCABundle(ctx, vp.client, cp.Namespace, creds.caCert, cpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// caBundle, when set, is mounted into the shoot-side CSI node pods so that Cinder/Manila CSI can
+	// verify Keystone/Cinder/Manila endpoints signed by a private PKI.
+	values := map[string]interface{}{}
+	if caBundle != "" {
+		values["caBundle"] = caBundle
+	}
+
+	if creds.workloadIdentity() {
+		cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+		if err != nil {
+			return nil, err
+		}
+		resolveWorkloadIdentityDefaults(creds, cloudProfileConfig)
+		values["workloadIdentity"] = workloadIdentityChartValues(creds)
+	}
+
+	if shareTypes := manilaShareTypes(cpConfig); len(shareTypes) > 0 {
+		values["csi-manila-storage-classes"] = manilaStorageClassesChartValues(shareTypes)
+	}
+
+	logger.V(5).Info("rendered shoot control plane chart values", "namespace", cp.Namespace, "values", redact.Redact(values))
+
+	return values, nil
+}
+
+// manilaShareTypes returns the declared Manila share types, or nil if CSI Manila is not configured.
+func manilaShareTypes(cpConfig *api.ControlPlaneConfig) []api.ManilaShareType {
+	if cpConfig.CSI == nil || cpConfig.CSI.Manila == nil {
+		return nil
+	}
+	return cpConfig.CSI.Manila.ShareTypes
+}
+
+// manilaNodePublishSecretName derives the name of the per-share-type secret that carries the
+// csi.storage.k8s.io/node-publish-secret-* credentials referenced by the rendered StorageClass.
+func manilaNodePublishSecretName(shareTypeName string) string {
+	return "csi-manila-nodeplugin-" + shareTypeName
+}
+
+// manilaNodePublishSecretsChartValues renders, for each declared share type, the name of the per-class secret
+// that the csi-manila-storage-classes chart creates in the Seed's control plane namespace.
+func manilaNodePublishSecretsChartValues(shareTypes []api.ManilaShareType) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(shareTypes))
+	for _, shareType := range shareTypes {
+		result = append(result, map[string]interface{}{
+			"name":       shareType.Name,
+			"secretName": manilaNodePublishSecretName(shareType.Name),
+		})
+	}
+	return result
+}
+
+// manilaStorageClassesChartValues renders one StorageClass entry per declared share type, referencing the
+// per-class node-publish-secret created by GetControlPlaneChartValues. ShareType.Backend and .ExtraSpecs, when
+// set, are passed through as additional StorageClass parameters, and .DefaultAccessRules are rendered as a
+// top-level accessRules entry applied by the csi-manila-storage-classes chart to every share provisioned with
+// the class.
+func manilaStorageClassesChartValues(shareTypes []api.ManilaShareType) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(shareTypes))
+	for _, shareType := range shareTypes {
+		secretName := manilaNodePublishSecretName(shareType.Name)
+		parameters := map[string]interface{}{
+			"type": shareType.Name,
+			"csi.storage.k8s.io/node-publish-secret-name":      secretName,
+			"csi.storage.k8s.io/node-publish-secret-namespace": "kube-system",
+		}
+		if shareType.Backend != "" {
+			parameters["backend"] = shareType.Backend
+		}
+		for k, v := range shareType.ExtraSpecs {
+			parameters[k] = v
+		}
+
+		storageClass := map[string]interface{}{
+			"name":        shareType.Name,
+			"provisioner": openstack.CSIManilaStorageProvisioner,
+			"parameters":  parameters,
+		}
+		if len(shareType.DefaultAccessRules) > 0 {
+			storageClass["accessRules"] = manilaAccessRulesChartValues(shareType.DefaultAccessRules)
+		}
+		result = append(result, storageClass)
+	}
+	return result
+}
+
+// manilaAccessRulesChartValues renders the chart-value shape of a ManilaShareType's DefaultAccessRules.
+func manilaAccessRulesChartValues(rules []api.ManilaAccessRule) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, map[string]interface{}{
+			"accessType":  rule.AccessType,
+			"accessTo":    rule.AccessTo,
+			"accessLevel": rule.AccessLevel,
+		})
+	}
+	return result
+}
+
+// GetControlPlaneShootCRDsChartValues returns the values for the CRDs chart applied to the Shoot.
+func (vp *valuesProvider) GetControlPlaneShootCRDsChartValues(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
+// GetStorageClassesChartValues returns the values for the storage classes chart applied to the Shoot.
+func (vp *valuesProvider) GetStorageClassesChartValues(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	storageClasses := []map[string]interface{}{
+		{
+			"name":        "default",
+			"default":     true,
+			"provisioner": openstack.CSIStorageProvisioner,
+		},
+		{
+			"name":          "retain",
+			"default":       false,
+			"provisioner":   openstack.CSIStorageProvisioner,
+			"reclaimPolicy": "Retain",
+		},
+	}
+
+	return map[string]interface{}{"storageclasses": storageClasses}, nil
+}
+
+// GetVolumeSnapshotClassesChartValues returns the values for the snapshot classes chart applied to the Shoot.
+func (vp *valuesProvider) GetVolumeSnapshotClassesChartValues(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	cpConfig := &api.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if err := json.Unmarshal(cp.Spec.ProviderConfig.Raw, cpConfig); err != nil {
+			return nil, fmt.Errorf("could not decode provider config: %w", err)
+		}
+	}
+
+	snapshotClasses := make([]map[string]interface{}, 0, len(cpConfig.SnapshotClasses))
+	for _, snapshotClass := range cpConfig.SnapshotClasses {
+		snapshotClasses = append(snapshotClasses, snapshotClassChartValues(snapshotClass))
+	}
+
+	return map[string]interface{}{"snapshotclasses": snapshotClasses}, nil
+}
+
+// snapshotClassChartValues renders a single declared SnapshotClass, applying the driver/deletionPolicy
+// defaults documented on the SnapshotClass type.
+func snapshotClassChartValues(snapshotClass api.SnapshotClass) map[string]interface{} {
+	driver := snapshotClass.Driver
+	if driver == "" {
+		driver = openstack.CSIStorageProvisioner
+	}
+
+	deletionPolicy := snapshotClass.DeletionPolicy
+	if deletionPolicy == "" {
+		deletionPolicy = "Delete"
+	}
+
+	value := map[string]interface{}{
+		"name":           snapshotClass.Name,
+		"driver":         driver,
+		"deletionPolicy": deletionPolicy,
+		"default":        snapshotClass.Default,
+	}
+	if len(snapshotClass.Parameters) > 0 {
+		value["parameters"] = snapshotClass.Parameters
+	}
+	return value
+}
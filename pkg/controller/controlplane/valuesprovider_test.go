@@ -36,8 +36,10 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -88,6 +90,15 @@ func defaultControlPlaneWithManila(csiManila bool) *extensionsv1alpha1.ControlPl
 	return cp
 }
 
+func controlPlaneWithManilaShareTypes(shareTypes []api.ManilaShareType) *extensionsv1alpha1.ControlPlane {
+	cp := defaultControlPlaneWithManila(true)
+	cpConfig := &api.ControlPlaneConfig{}
+	Expect(json.Unmarshal(cp.Spec.ProviderConfig.Raw, cpConfig)).To(Succeed())
+	cpConfig.CSI.Manila.ShareTypes = shareTypes
+	cp.Spec.ProviderConfig.Raw = encode(cpConfig)
+	return cp
+}
+
 func controlPlane(floatingPoolID string, cfg *api.ControlPlaneConfig, status *api.ShareNetworkStatus) *extensionsv1alpha1.ControlPlane {
 	return &extensionsv1alpha1.ControlPlane{
 		ObjectMeta: metav1.ObjectMeta{
@@ -253,10 +264,11 @@ var _ = Describe("ValuesProvider", func() {
 			},
 		}
 
-		domainName  = "domain-name"
-		tenantName  = "tenant-name"
-		cpSecretKey = client.ObjectKey{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}
-		cpSecret    = &corev1.Secret{
+		domainName           = "domain-name"
+		tenantName           = "tenant-name"
+		cpSecretKey          = client.ObjectKey{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}
+		caBundleConfigMapKey = client.ObjectKey{Namespace: namespace, Name: openstack.TrustedCABundleConfigMapName}
+		cpSecret             = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      v1beta1constants.SecretNameCloudProvider,
 				Namespace: namespace,
@@ -271,6 +283,22 @@ var _ = Describe("ValuesProvider", func() {
 			},
 		}
 
+		cpSecretWorkloadIdentity = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1beta1constants.SecretNameCloudProvider,
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"domainName":         []byte(domainName),
+				"tenantName":         []byte(tenantName),
+				"identityProviderID": []byte("my-identity-provider"),
+				"protocol":           []byte("openid"),
+				"clientID":           []byte("my-client-id"),
+				"authURL":            []byte(authURL),
+			},
+		}
+
 		cpConfigKey = client.ObjectKey{Namespace: namespace, Name: openstack.CloudProviderConfigName}
 		cpConfig    = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -304,6 +332,8 @@ var _ = Describe("ValuesProvider", func() {
 
 		enabledTrue  = map[string]interface{}{"enabled": true}
 		enabledFalse = map[string]interface{}{"enabled": false}
+
+		notFoundCABundleConfigMap = apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, openstack.TrustedCABundleConfigMapName)
 	)
 
 	BeforeEach(func() {
@@ -351,6 +381,7 @@ var _ = Describe("ValuesProvider", func() {
 
 		It("should return correct config chart values", func() {
 			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
 
 			values, err := vp.GetConfigChartValues(ctx, cp, clusterK8sAtLeast120)
 			Expect(err).NotTo(HaveOccurred())
@@ -359,6 +390,7 @@ var _ = Describe("ValuesProvider", func() {
 
 		It("should return correct config chart values with load balancer classes", func() {
 			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
 
 			var (
 				floatingNetworkID  = "4711"
@@ -451,6 +483,7 @@ var _ = Describe("ValuesProvider", func() {
 
 		It("should return correct config chart values with load balancer classes with purpose", func() {
 			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
 
 			var (
 				floatingNetworkID = "fip1"
@@ -510,6 +543,7 @@ var _ = Describe("ValuesProvider", func() {
 			}
 
 			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(&secret2))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
 
 			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
 				"username":                    "",
@@ -523,8 +557,73 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(values).To(Equal(expectedValues))
 		})
 
+		It("should return correct config chart values with workload identity", func() {
+			secret2 := *cpSecret
+			secret2.Data = map[string][]byte{
+				"domainName":         []byte(domainName),
+				"tenantName":         []byte(tenantName),
+				"identityProviderID": []byte("my-identity-provider"),
+				"protocol":           []byte("openid"),
+				"clientID":           []byte("my-client-id"),
+				"authURL":            []byte(authURL),
+			}
+
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(&secret2))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
+
+			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
+				"username":                    "",
+				"password":                    "",
+				"applicationCredentialID":     "",
+				"applicationCredentialSecret": "",
+				"applicationCredentialName":   "",
+				"identityProviderID":          "my-identity-provider",
+				"protocol":                    "openid",
+				"clientID":                    "my-client-id",
+				"tokenAudience":               ServiceAccountTokenAudienceDefault,
+				"tokenFilePath":               ServiceAccountTokenFilePathDefault,
+			})
+			values, err := vp.GetConfigChartValues(ctx, cp, clusterK8sAtLeast120)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
+		It("should honor a workload identity tokenAudience/tokenFilePath given in the cloudprovider secret", func() {
+			secret2 := *cpSecret
+			secret2.Data = map[string][]byte{
+				"domainName":         []byte(domainName),
+				"tenantName":         []byte(tenantName),
+				"identityProviderID": []byte("my-identity-provider"),
+				"protocol":           []byte("openid"),
+				"clientID":           []byte("my-client-id"),
+				"tokenAudience":      []byte("my-audience"),
+				"tokenFilePath":      []byte("/var/run/my-token"),
+				"authURL":            []byte(authURL),
+			}
+
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(&secret2))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
+
+			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
+				"username":                    "",
+				"password":                    "",
+				"applicationCredentialID":     "",
+				"applicationCredentialSecret": "",
+				"applicationCredentialName":   "",
+				"identityProviderID":          "my-identity-provider",
+				"protocol":                    "openid",
+				"clientID":                    "my-client-id",
+				"tokenAudience":               "my-audience",
+				"tokenFilePath":               "/var/run/my-token",
+			})
+			values, err := vp.GetConfigChartValues(ctx, cp, clusterK8sAtLeast120)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
 		It("should configure cloud routes when not using overlay", func() {
 			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
 			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
 				"routerID": "routerID",
 			})
@@ -538,8 +637,103 @@ var _ = Describe("ValuesProvider", func() {
 			caCert := "custom-cert"
 			secret2.Data["caCert"] = []byte(caCert)
 			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret2))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
 			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
-				"caCert": caCert,
+				"caCert":   caCert,
+				"caBundle": caCert,
+			})
+			values, err := vp.GetConfigChartValues(ctx, cp, clusterK8sAtLeast120)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
+		It("should merge the cluster-wide trusted CA bundle with the secret's caCert", func() {
+			secret2 := cpSecret.DeepCopy()
+			caCert := "secret-cert"
+			secret2.Data["caCert"] = []byte(caCert)
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret2))
+
+			bundleConfigMap := &corev1.ConfigMap{
+				Data: map[string]string{
+					openstack.TrustedCABundleDataKey: "configmap-bundle",
+				},
+			}
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(bundleConfigMap))
+
+			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
+				"caCert":   caCert,
+				"caBundle": "configmap-bundle\n" + caCert,
+			})
+			values, err := vp.GetConfigChartValues(ctx, cp, clusterK8sAtLeast120)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
+		It("should merge a shoot-referenced CA certificate configmap into the trusted CA bundle", func() {
+			secret2 := cpSecret.DeepCopy()
+			caCert := "secret-cert"
+			secret2.Data["caCert"] = []byte(caCert)
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret2))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
+
+			shootCAConfigMapName := "my-trusted-cas"
+			shootCAConfigMapKey := client.ObjectKey{Namespace: namespace, Name: shootCAConfigMapName}
+			shootCAConfigMap := &corev1.ConfigMap{
+				Data: map[string]string{
+					openstack.ShootCACertificateDataKey: "shoot-bundle",
+				},
+			}
+			c.EXPECT().Get(ctx, shootCAConfigMapKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(shootCAConfigMap))
+
+			cpWithCARef := controlPlane(
+				"floating-network-id",
+				&api.ControlPlaneConfig{
+					LoadBalancerProvider: "load-balancer-provider",
+					CloudControllerManager: &api.CloudControllerManagerConfig{
+						FeatureGates: map[string]bool{
+							"CustomResourceValidation": true,
+						},
+					},
+					CACertificateConfigMapRef: &shootCAConfigMapName,
+				},
+				nil,
+			)
+
+			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
+				"caCert":   caCert,
+				"caBundle": "shoot-bundle\n" + caCert,
+			})
+			values, err := vp.GetConfigChartValues(ctx, cpWithCARef, clusterK8sAtLeast120)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
+		It("should override the default Cinder block storage behavior", func() {
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			c.EXPECT().Get(ctx, caBundleConfigMapKey, &corev1.ConfigMap{}).Return(notFoundCABundleConfigMap)
+
+			bsVersion := "v3"
+			storageIgnoreVolumeAZ := true
+			cp := controlPlane(
+				"floating-network-id",
+				&api.ControlPlaneConfig{
+					LoadBalancerProvider: "load-balancer-provider",
+					CloudControllerManager: &api.CloudControllerManagerConfig{
+						FeatureGates: map[string]bool{
+							"CustomResourceValidation": true,
+						},
+					},
+					Storage: &api.Storage{
+						BSVersion:      &bsVersion,
+						IgnoreVolumeAZ: &storageIgnoreVolumeAZ,
+					},
+				},
+				nil,
+			)
+
+			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
+				"bsVersion":      bsVersion,
+				"ignoreVolumeAZ": storageIgnoreVolumeAZ,
 			})
 			values, err := vp.GetConfigChartValues(ctx, cp, clusterK8sAtLeast120)
 			Expect(err).NotTo(HaveOccurred())
@@ -618,6 +812,69 @@ var _ = Describe("ValuesProvider", func() {
 				}),
 			}))
 		})
+
+		It("should render the per-share-type node-publish secrets when CSI Manila share types are declared", func() {
+			c.EXPECT().Get(ctx, cpCSIDiskConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cpCSIDiskConfig))
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			cpManila := controlPlaneWithManilaShareTypes([]api.ManilaShareType{
+				{Name: "default", Backend: "generic"},
+				{Name: "fast", Backend: "netapp"},
+			})
+			values, err := vp.GetControlPlaneChartValues(ctx, cpManila, clusterK8sAtLeast120, fakeSecretsManager, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["csi-manila-storage-classes"]).To(Equal([]map[string]interface{}{
+				{"name": "default", "secretName": "csi-manila-nodeplugin-default"},
+				{"name": "fast", "secretName": "csi-manila-nodeplugin-fast"},
+			}))
+		})
+
+		It("should render the workload identity OIDC fields when the secret carries no static credentials", func() {
+			c.EXPECT().Get(ctx, cpCSIDiskConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cpCSIDiskConfig))
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecretWorkloadIdentity))
+
+			values, err := vp.GetControlPlaneChartValues(ctx, cp, clusterK8sAtLeast120, fakeSecretsManager, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["workloadIdentity"]).To(Equal(map[string]interface{}{
+				"identityProviderID": "my-identity-provider",
+				"protocol":           "openid",
+				"clientID":           "my-client-id",
+				"tokenAudience":      ServiceAccountTokenAudienceDefault,
+				"tokenFilePath":      ServiceAccountTokenFilePathDefault,
+			}))
+		})
+
+		It("should merge a shoot-referenced CA certificate configmap into the rendered caBundle", func() {
+			c.EXPECT().Get(ctx, cpCSIDiskConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cpCSIDiskConfig))
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			shootCAConfigMapName := "my-trusted-cas"
+			shootCAConfigMapKey := client.ObjectKey{Namespace: namespace, Name: shootCAConfigMapName}
+			shootCAConfigMap := &corev1.ConfigMap{
+				Data: map[string]string{
+					openstack.ShootCACertificateDataKey: "shoot-bundle",
+				},
+			}
+			c.EXPECT().Get(ctx, shootCAConfigMapKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(shootCAConfigMap))
+
+			cpWithCARef := controlPlane(
+				"floating-network-id",
+				&api.ControlPlaneConfig{
+					LoadBalancerProvider: "load-balancer-provider",
+					CloudControllerManager: &api.CloudControllerManagerConfig{
+						FeatureGates: map[string]bool{
+							"CustomResourceValidation": true,
+						},
+					},
+					CACertificateConfigMapRef: &shootCAConfigMapName,
+				},
+				nil,
+			)
+
+			values, err := vp.GetControlPlaneChartValues(ctx, cpWithCARef, clusterK8sAtLeast120, fakeSecretsManager, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["caBundle"]).To(Equal("shoot-bundle"))
+		})
 	})
 
 	Describe("#GetControlPlaneShootChartValues", func() {
@@ -708,6 +965,144 @@ var _ = Describe("ValuesProvider", func() {
 			})
 		})
 
+		Context("csi-manila-storage-classes", func() {
+			It("should render one StorageClass per declared Manila share type", func() {
+				c.EXPECT().Get(ctx, cpCSIDiskConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cpCSIDiskConfig))
+				c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+				cpManila := controlPlaneWithManilaShareTypes([]api.ManilaShareType{
+					{Name: "default", Backend: "generic"},
+					{Name: "fast", Backend: "netapp"},
+				})
+				values, err := vp.GetControlPlaneShootChartValues(ctx, cpManila, clusterK8sAtLeast120, fakeSecretsManager, map[string]string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(values["csi-manila-storage-classes"]).To(Equal([]map[string]interface{}{
+					{
+						"name":        "default",
+						"provisioner": openstack.CSIManilaStorageProvisioner,
+						"parameters": map[string]interface{}{
+							"type":    "default",
+							"backend": "generic",
+							"csi.storage.k8s.io/node-publish-secret-name":      "csi-manila-nodeplugin-default",
+							"csi.storage.k8s.io/node-publish-secret-namespace": "kube-system",
+						},
+					},
+					{
+						"name":        "fast",
+						"provisioner": openstack.CSIManilaStorageProvisioner,
+						"parameters": map[string]interface{}{
+							"type":    "fast",
+							"backend": "netapp",
+							"csi.storage.k8s.io/node-publish-secret-name":      "csi-manila-nodeplugin-fast",
+							"csi.storage.k8s.io/node-publish-secret-namespace": "kube-system",
+						},
+					},
+				}))
+			})
+
+			It("should drop the StorageClass for a share type removed from the spec", func() {
+				c.EXPECT().Get(ctx, cpCSIDiskConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cpCSIDiskConfig))
+				c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+				cpManila := controlPlaneWithManilaShareTypes([]api.ManilaShareType{
+					{Name: "default", Backend: "generic"},
+				})
+				values, err := vp.GetControlPlaneShootChartValues(ctx, cpManila, clusterK8sAtLeast120, fakeSecretsManager, map[string]string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(values["csi-manila-storage-classes"]).To(Equal([]map[string]interface{}{
+					{
+						"name":        "default",
+						"provisioner": openstack.CSIManilaStorageProvisioner,
+						"parameters": map[string]interface{}{
+							"type":    "default",
+							"backend": "generic",
+							"csi.storage.k8s.io/node-publish-secret-name":      "csi-manila-nodeplugin-default",
+							"csi.storage.k8s.io/node-publish-secret-namespace": "kube-system",
+						},
+					},
+				}))
+			})
+
+			It("should pass through ExtraSpecs and render DefaultAccessRules for a declared share type", func() {
+				c.EXPECT().Get(ctx, cpCSIDiskConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cpCSIDiskConfig))
+				c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+				cpManila := controlPlaneWithManilaShareTypes([]api.ManilaShareType{
+					{
+						Name:               "default",
+						Backend:            "generic",
+						ExtraSpecs:         map[string]string{"snapshot_support": "true"},
+						DefaultAccessRules: []api.ManilaAccessRule{{AccessType: "ip", AccessTo: "10.0.0.0/8", AccessLevel: "rw"}},
+					},
+				})
+				values, err := vp.GetControlPlaneShootChartValues(ctx, cpManila, clusterK8sAtLeast120, fakeSecretsManager, map[string]string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(values["csi-manila-storage-classes"]).To(Equal([]map[string]interface{}{
+					{
+						"name":        "default",
+						"provisioner": openstack.CSIManilaStorageProvisioner,
+						"parameters": map[string]interface{}{
+							"type":             "default",
+							"backend":          "generic",
+							"snapshot_support": "true",
+							"csi.storage.k8s.io/node-publish-secret-name":      "csi-manila-nodeplugin-default",
+							"csi.storage.k8s.io/node-publish-secret-namespace": "kube-system",
+						},
+						"accessRules": []map[string]interface{}{
+							{"accessType": "ip", "accessTo": "10.0.0.0/8", "accessLevel": "rw"},
+						},
+					},
+				}))
+			})
+		})
+
+		It("should render the workload identity OIDC fields when the secret carries no static credentials", func() {
+			c.EXPECT().Get(ctx, cpCSIDiskConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cpCSIDiskConfig))
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecretWorkloadIdentity))
+
+			values, err := vp.GetControlPlaneShootChartValues(ctx, cp, clusterK8sAtLeast120, fakeSecretsManager, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["workloadIdentity"]).To(Equal(map[string]interface{}{
+				"identityProviderID": "my-identity-provider",
+				"protocol":           "openid",
+				"clientID":           "my-client-id",
+				"tokenAudience":      ServiceAccountTokenAudienceDefault,
+				"tokenFilePath":      ServiceAccountTokenFilePathDefault,
+			}))
+		})
+
+		It("should merge a shoot-referenced CA certificate configmap into the rendered caBundle", func() {
+			c.EXPECT().Get(ctx, cpCSIDiskConfigKey, &corev1.Secret{}).DoAndReturn(clientGet(cpCSIDiskConfig))
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			shootCAConfigMapName := "my-trusted-cas"
+			shootCAConfigMapKey := client.ObjectKey{Namespace: namespace, Name: shootCAConfigMapName}
+			shootCAConfigMap := &corev1.ConfigMap{
+				Data: map[string]string{
+					openstack.ShootCACertificateDataKey: "shoot-bundle",
+				},
+			}
+			c.EXPECT().Get(ctx, shootCAConfigMapKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(shootCAConfigMap))
+
+			cpWithCARef := controlPlane(
+				"floating-network-id",
+				&api.ControlPlaneConfig{
+					LoadBalancerProvider: "load-balancer-provider",
+					CloudControllerManager: &api.CloudControllerManagerConfig{
+						FeatureGates: map[string]bool{
+							"CustomResourceValidation": true,
+						},
+					},
+					CACertificateConfigMapRef: &shootCAConfigMapName,
+				},
+				nil,
+			)
+
+			values, err := vp.GetControlPlaneShootChartValues(ctx, cpWithCARef, clusterK8sAtLeast120, fakeSecretsManager, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["caBundle"]).To(Equal("shoot-bundle"))
+		})
+
 		Context("PodSecurityPolicy", func() {
 			It("should return correct shoot control plane chart when PodSecurityPolicy admission plugin is not disabled in the shoot", func() {
 				clusterK8sAtLeast120.Shoot.Spec.Kubernetes.KubeAPIServer = &gardencorev1beta1.KubeAPIServerConfig{
@@ -786,6 +1181,221 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(values["storageclasses"].([]map[string]interface{})[1]["provisioner"]).To(Equal(openstack.CSIStorageProvisioner))
 		})
 	})
+
+	Describe("#GetVolumeSnapshotClassesChartValues", func() {
+		It("should return an empty list when no snapshot classes are declared", func() {
+			values, err := vp.GetVolumeSnapshotClassesChartValues(ctx, cp, clusterK8sAtLeast120)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["snapshotclasses"]).To(BeEmpty())
+		})
+
+		It("should render one VolumeSnapshotClass per declared snapshot class, applying driver/deletionPolicy defaults", func() {
+			cpConfig := &api.ControlPlaneConfig{
+				SnapshotClasses: []api.SnapshotClass{
+					{
+						Name:    "default",
+						Default: true,
+					},
+					{
+						Name:           "manila",
+						Driver:         openstack.CSIManilaStorageProvisioner,
+						DeletionPolicy: "Retain",
+						Parameters: map[string]string{
+							"snapshot_type": "default_snapshot_type",
+						},
+					},
+				},
+			}
+			cpSnapshot := controlPlane("floating-network-id", cpConfig, nil)
+
+			values, err := vp.GetVolumeSnapshotClassesChartValues(ctx, cpSnapshot, clusterK8sAtLeast120)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["snapshotclasses"]).To(Equal([]map[string]interface{}{
+				{
+					"name":           "default",
+					"driver":         openstack.CSIStorageProvisioner,
+					"deletionPolicy": "Delete",
+					"default":        true,
+				},
+				{
+					"name":           "manila",
+					"driver":         openstack.CSIManilaStorageProvisioner,
+					"deletionPolicy": "Retain",
+					"default":        false,
+					"parameters": map[string]string{
+						"snapshot_type": "default_snapshot_type",
+					},
+				},
+			}))
+		})
+	})
+})
+
+// fakeApplicationCredentialClient is an in-memory ApplicationCredentialClient used to test application
+// credential management without a real Keystone.
+type fakeApplicationCredentialClient struct {
+	nextID      string
+	created     []string
+	deleted     []string
+	createCalls int
+	deleteCalls int
+	createErr   error
+	deleteErr   error
+}
+
+func (f *fakeApplicationCredentialClient) CreateApplicationCredential(_ context.Context, _, name string) (string, string, error) {
+	f.createCalls++
+	if f.createErr != nil {
+		return "", "", f.createErr
+	}
+	f.created = append(f.created, name)
+	return f.nextID, f.nextID + "-secret", nil
+}
+
+func (f *fakeApplicationCredentialClient) DeleteApplicationCredential(_ context.Context, _, id string) error {
+	f.deleteCalls++
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+var _ = Describe("#ensureManagedApplicationCredential", func() {
+	var (
+		ctx           = context.TODO()
+		appCredClient *fakeApplicationCredentialClient
+		fakeClient    client.Client
+		creds         *credentials
+	)
+
+	BeforeEach(func() {
+		appCredClient = &fakeApplicationCredentialClient{nextID: "new-cred-id"}
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		creds = &credentials{
+			manageAppCredentials: true,
+			username:             "jdoe",
+			password:             "s3cret",
+		}
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.SecretNameCloudProvider, Namespace: namespace},
+			Data:       map[string][]byte{"username": []byte("jdoe"), "password": []byte("s3cret")},
+		})).To(Succeed())
+	})
+
+	It("is a no-op when the secret does not opt in", func() {
+		creds.manageAppCredentials = false
+		Expect(ensureManagedApplicationCredential(ctx, fakeClient, appCredClient, namespace, creds, nil)).To(Succeed())
+		Expect(appCredClient.createCalls).To(Equal(0))
+		Expect(creds.applicationCredentialID).To(BeEmpty())
+	})
+
+	It("creates a managed application credential when none exists yet", func() {
+		Expect(ensureManagedApplicationCredential(ctx, fakeClient, appCredClient, namespace, creds, nil)).To(Succeed())
+
+		Expect(appCredClient.createCalls).To(Equal(1))
+		Expect(appCredClient.deleteCalls).To(Equal(0))
+		Expect(creds.applicationCredentialID).To(Equal("new-cred-id"))
+		Expect(creds.applicationCredentialSecret).To(Equal("new-cred-id-secret"))
+
+		secret := &corev1.Secret{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}, secret)).To(Succeed())
+		Expect(string(secret.Data["applicationCredentialID"])).To(Equal("new-cred-id"))
+		Expect(string(secret.Data["applicationCredentialSecret"])).To(Equal("new-cred-id-secret"))
+
+		state, err := getApplicationCredentialState(ctx, fakeClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.CredentialID).To(Equal("new-cred-id"))
+		Expect(state.PreviousCredentialID).To(BeEmpty())
+	})
+
+	It("recovers from a crash mid-rotation by deleting the previous credential without creating a new one", func() {
+		creds.applicationCredentialID = "new-cred-id"
+		Expect(saveApplicationCredentialState(ctx, fakeClient, namespace, &applicationCredentialState{
+			CredentialID:         "new-cred-id",
+			CredentialName:       "gardener-test-1",
+			CreatedAt:            time.Now(),
+			PreviousCredentialID: "old-cred-id",
+		})).To(Succeed())
+
+		Expect(ensureManagedApplicationCredential(ctx, fakeClient, appCredClient, namespace, creds, nil)).To(Succeed())
+
+		Expect(appCredClient.createCalls).To(Equal(0))
+		Expect(appCredClient.deleteCalls).To(Equal(1))
+		Expect(appCredClient.deleted).To(ConsistOf("old-cred-id"))
+		Expect(creds.applicationCredentialID).To(Equal("new-cred-id"))
+
+		state, err := getApplicationCredentialState(ctx, fakeClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.PreviousCredentialID).To(BeEmpty())
+	})
+
+	It("does not delete the previous credential in the same reconciliation that rotates it", func() {
+		Expect(saveApplicationCredentialState(ctx, fakeClient, namespace, &applicationCredentialState{
+			CredentialID:   "old-cred-id",
+			CredentialName: "gardener-test-0",
+			CreatedAt:      time.Now().Add(-100 * 24 * time.Hour),
+		})).To(Succeed())
+		creds.applicationCredentialID = "old-cred-id"
+
+		rotation := &api.ApplicationCredentialRotation{RotationPeriod: &metav1.Duration{Duration: 24 * time.Hour}}
+		Expect(ensureManagedApplicationCredential(ctx, fakeClient, appCredClient, namespace, creds, rotation)).To(Succeed())
+
+		Expect(appCredClient.createCalls).To(Equal(1))
+		Expect(appCredClient.deleteCalls).To(Equal(0))
+
+		state, err := getApplicationCredentialState(ctx, fakeClient, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.CredentialID).To(Equal("new-cred-id"))
+		Expect(state.PreviousCredentialID).To(Equal("old-cred-id"))
+	})
+})
+
+var _ = Describe("#DeleteManagedApplicationCredential", func() {
+	var (
+		ctx           = context.TODO()
+		appCredClient *fakeApplicationCredentialClient
+		fakeClient    client.Client
+	)
+
+	BeforeEach(func() {
+		appCredClient = &fakeApplicationCredentialClient{nextID: "unused"}
+		fakeClient = fakeclient.NewClientBuilder().Build()
+	})
+
+	It("is a no-op when no credential was ever created", func() {
+		Expect(DeleteManagedApplicationCredential(ctx, fakeClient, appCredClient, namespace, "jdoe")).To(Succeed())
+		Expect(appCredClient.deleteCalls).To(Equal(0))
+	})
+
+	It("deletes the managed credential and its state configmap on ControlPlane deletion", func() {
+		Expect(saveApplicationCredentialState(ctx, fakeClient, namespace, &applicationCredentialState{
+			CredentialID:   "active-cred-id",
+			CredentialName: "gardener-test-1",
+			CreatedAt:      time.Now(),
+		})).To(Succeed())
+
+		Expect(DeleteManagedApplicationCredential(ctx, fakeClient, appCredClient, namespace, "jdoe")).To(Succeed())
+
+		Expect(appCredClient.deleted).To(ConsistOf("active-cred-id"))
+
+		configMap := &corev1.ConfigMap{}
+		err := fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: openstack.ApplicationCredentialStateConfigMapName}, configMap)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("also deletes a lingering previous credential left over from an interrupted rotation", func() {
+		Expect(saveApplicationCredentialState(ctx, fakeClient, namespace, &applicationCredentialState{
+			CredentialID:         "active-cred-id",
+			CredentialName:       "gardener-test-2",
+			CreatedAt:            time.Now(),
+			PreviousCredentialID: "stale-cred-id",
+		})).To(Succeed())
+
+		Expect(DeleteManagedApplicationCredential(ctx, fakeClient, appCredClient, namespace, "jdoe")).To(Succeed())
+
+		Expect(appCredClient.deleted).To(ConsistOf("active-cred-id", "stale-cred-id"))
+	})
 })
 
 func encode(obj runtime.Object) []byte {
@@ -798,6 +1408,8 @@ func clientGet(result runtime.Object) interface{} {
 		switch obj.(type) {
 		case *corev1.Secret:
 			*obj.(*corev1.Secret) = *result.(*corev1.Secret)
+		case *corev1.ConfigMap:
+			*obj.(*corev1.ConfigMap) = *result.(*corev1.ConfigMap)
 		}
 		return nil
 	}
@@ -0,0 +1,145 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package infrastructure contains the reconciler that provisions the OpenStack infrastructure for a Shoot,
+// either via the Terraformer or, when enabled, the native gophercloud based reconciler.
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/gardener/gardener/extensions/pkg/terraformer"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apiserver/pkg/util/feature"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+	infrainternal "github.com/gardener/gardener-extension-provider-openstack/pkg/internal/infrastructure"
+)
+
+// actuator reconciles and deletes the OpenStack infrastructure for a Shoot.
+type actuator struct {
+	client         client.Client
+	newClient      func(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) (infrainternal.Client, error)
+	newTerraformer func(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) (terraformer.Terraformer, error)
+}
+
+// NewActuator creates a new infrastructure.Actuator. c is used to persist the Terraform config and state as
+// ConfigMaps/Secrets in the shoot's control plane namespace.
+func NewActuator(
+	c client.Client,
+	newClient func(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) (infrainternal.Client, error),
+	newTerraformer func(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) (terraformer.Terraformer, error),
+) *actuator {
+	return &actuator{client: c, newClient: newClient, newTerraformer: newTerraformer}
+}
+
+// Reconcile provisions the infrastructure. When FeatureGateNativeReconciler is enabled it first attempts the
+// native, gophercloud-backed flow; if that flow reports ErrUnrecognizedResource (e.g. because the
+// InfrastructureConfig uses a shape the native reconciler does not yet support), it transparently falls back to
+// the Terraformer so that the shoot is never left stuck behind an unsupported feature.
+func (a *actuator) Reconcile(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, config *api.InfrastructureConfig, cluster *controller.Cluster, oldState *infrainternal.InfrastructureState) (*apiv1alpha1.InfrastructureStatus, *infrainternal.InfrastructureState, error) {
+	if feature.DefaultFeatureGate.Enabled(infrainternal.FeatureGateNativeReconciler) {
+		status, state, err := a.reconcileNative(ctx, infra, config, oldState)
+		if err == nil {
+			return status, state, nil
+		}
+		if !errors.Is(err, infrainternal.ErrUnrecognizedResource) {
+			return nil, nil, err
+		}
+		// fall through to the Terraformer flow below.
+	}
+
+	return a.reconcileTerraformer(ctx, infra, config, cluster)
+}
+
+// reconcileTerraformer renders the OpenStack infrastructure Terraform config, applies it via tf, and computes
+// the resulting InfrastructureStatus from the Terraformer's state outputs. It does not produce an
+// InfrastructureState, since that sub-resource only exists to support the native reconciler flow; the
+// Terraformer flow keeps using its own Terraform state as the source of truth.
+func (a *actuator) reconcileTerraformer(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, config *api.InfrastructureConfig, cluster *controller.Cluster) (*apiv1alpha1.InfrastructureStatus, *infrainternal.InfrastructureState, error) {
+	terraformFiles, err := infrainternal.RenderTerraformerTemplate(infra, config, cluster)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not render terraformer template: %w", err)
+	}
+
+	tf, err := a.newTerraformer(ctx, infra)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create terraformer: %w", err)
+	}
+
+	if err := tf.
+		InitializeWith(ctx, terraformer.DefaultInitializer(a.client, terraformFiles.Main, terraformFiles.Variables, terraformFiles.TFVars, terraformer.CreateState)).
+		Apply(ctx); err != nil {
+		return nil, nil, fmt.Errorf("could not apply terraform config: %w", err)
+	}
+
+	status, err := infrainternal.ComputeStatus(ctx, tf, config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not compute terraformer status: %w", err)
+	}
+
+	return status, nil, nil
+}
+
+func (a *actuator) reconcileNative(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, config *api.InfrastructureConfig, oldState *infrainternal.InfrastructureState) (*apiv1alpha1.InfrastructureStatus, *infrainternal.InfrastructureState, error) {
+	client, err := a.newClient(ctx, infra)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create OpenStack client: %w", err)
+	}
+
+	state, err := infrainternal.Reconcile(ctx, client, infra, config, oldState)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return infrainternal.ComputeStatusFromState(config, state), state, nil
+}
+
+// Delete tears down the infrastructure previously provisioned by Reconcile. A nil state does not mean there is
+// nothing to tear down: the Terraformer flow never produces an InfrastructureState (see reconcileTerraformer), so
+// a nil state here is the common case for any shoot reconciled with FeatureGateNativeReconciler disabled, and
+// must fall back to destroying via the Terraformer rather than being treated as a no-op.
+func (a *actuator) Delete(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, state *infrainternal.InfrastructureState) error {
+	if state == nil {
+		return a.deleteTerraformer(ctx, infra)
+	}
+
+	client, err := a.newClient(ctx, infra)
+	if err != nil {
+		return fmt.Errorf("could not create OpenStack client: %w", err)
+	}
+
+	return infrainternal.Delete(ctx, client, state)
+}
+
+// deleteTerraformer destroys the infrastructure previously applied by reconcileTerraformer. It does not need to
+// re-render the Terraform config: the Terraformer reads back the Main/Variables/TFVars persisted as ConfigMaps/
+// Secrets by the prior Apply.
+func (a *actuator) deleteTerraformer(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) error {
+	tf, err := a.newTerraformer(ctx, infra)
+	if err != nil {
+		return fmt.Errorf("could not create terraformer: %w", err)
+	}
+
+	if err := tf.Destroy(ctx); err != nil {
+		return fmt.Errorf("could not destroy terraform config: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener/extensions/pkg/terraformer"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	infrainternal "github.com/gardener/gardener-extension-provider-openstack/pkg/internal/infrastructure"
+)
+
+// fakeTerraformer embeds the (unvendored) terraformer.Terraformer interface so that only the methods exercised
+// by actuator.go need to be overridden; every other method would nil-panic if called, which none of the tests
+// below trigger.
+type fakeTerraformer struct {
+	terraformer.Terraformer
+	destroyCalled int
+	destroyErr    error
+}
+
+func (f *fakeTerraformer) Destroy(_ context.Context) error {
+	f.destroyCalled++
+	return f.destroyErr
+}
+
+// fakeClient implements infrainternal.Client, recording which Delete* methods were called.
+type fakeClient struct {
+	infrainternal.Client
+	deletedRouterID string
+}
+
+func (f *fakeClient) DeleteRouter(_ context.Context, id string) error {
+	f.deletedRouterID = id
+	return nil
+}
+
+func (f *fakeClient) DeleteNetwork(_ context.Context, _ string) error       { return nil }
+func (f *fakeClient) DeleteSubnet(_ context.Context, _ string) error        { return nil }
+func (f *fakeClient) DeleteSecurityGroup(_ context.Context, _ string) error { return nil }
+func (f *fakeClient) DeleteSSHKeyPair(_ context.Context, _ string) error    { return nil }
+func (f *fakeClient) DeleteShareNetwork(_ context.Context, _ string) error  { return nil }
+
+var _ = Describe("actuator.Delete", func() {
+	var infra *extensionsv1alpha1.Infrastructure
+
+	BeforeEach(func() {
+		infra = &extensionsv1alpha1.Infrastructure{}
+	})
+
+	It("tears down via the Terraformer when no native InfrastructureState was persisted", func() {
+		tf := &fakeTerraformer{}
+		a := NewActuator(nil,
+			func(context.Context, *extensionsv1alpha1.Infrastructure) (infrainternal.Client, error) { return nil, nil },
+			func(context.Context, *extensionsv1alpha1.Infrastructure) (terraformer.Terraformer, error) { return tf, nil },
+		)
+
+		Expect(a.Delete(context.Background(), infra, nil)).To(Succeed())
+		Expect(tf.destroyCalled).To(Equal(1), "a nil state must still tear down the Terraformer-provisioned infrastructure")
+	})
+
+	It("tears down the native resources when an InfrastructureState was persisted", func() {
+		client := &fakeClient{}
+		a := NewActuator(nil,
+			func(context.Context, *extensionsv1alpha1.Infrastructure) (infrainternal.Client, error) { return client, nil },
+			func(context.Context, *extensionsv1alpha1.Infrastructure) (terraformer.Terraformer, error) {
+				return nil, nil
+			},
+		)
+
+		state := &infrainternal.InfrastructureState{RouterID: "router-1"}
+		Expect(a.Delete(context.Background(), infra, state)).To(Succeed())
+		Expect(client.deletedRouterID).To(Equal("router-1"))
+	})
+})
@@ -0,0 +1,95 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupbucket
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/validation"
+)
+
+// Backend is the object-store-specific half of the backupbucket actuator: reconciling the container, tearing it
+// down, and minting credentials scoped to it. The actuator selects an implementation based on
+// BackupBucketProvider.Backend; everything backend-agnostic (retention-downgrade rejection, replication health
+// computation) lives in Ensure/EnsureReplication and is shared by every Backend implementation.
+type Backend interface {
+	// Ensure provisions containerName and applies config's retention/replication settings, returning the
+	// resulting status.
+	Ensure(ctx context.Context, containerName string, config *api.BackupBucketConfig, oldStatus *apiv1alpha1.BackupBucketStatus) (*apiv1alpha1.BackupBucketStatus, error)
+	// Delete removes containerName and all objects in it.
+	Delete(ctx context.Context, containerName string) error
+	// GenerateSecret creates backend-scoped credentials restricted to containerName, to be written to a Secret
+	// referenced by the generic BackupBucket's Status.GeneratedSecretRef.
+	GenerateSecret(ctx context.Context, containerName string) (map[string][]byte, error)
+}
+
+// backend is the shared Backend implementation for both object-store backends: every backend-specific behavior
+// is already captured by its Client (a gophercloud-backed client for Swift, an S3-SDK-backed client for S3), so
+// NewSwiftBackend and NewS3Backend both return one of these, differing only in which Client they close over.
+type backend struct {
+	client Client
+}
+
+// NewSwiftBackend creates the Backend for BackupBucketBackendSwift, backed by client.
+func NewSwiftBackend(client Client) Backend {
+	return &backend{client: client}
+}
+
+// NewS3Backend creates the Backend for BackupBucketBackendS3, backed by client.
+func NewS3Backend(client Client) Backend {
+	return &backend{client: client}
+}
+
+func (b *backend) Ensure(ctx context.Context, containerName string, config *api.BackupBucketConfig, oldStatus *apiv1alpha1.BackupBucketStatus) (*apiv1alpha1.BackupBucketStatus, error) {
+	return Ensure(ctx, b.client, containerName, config, oldStatus)
+}
+
+func (b *backend) Delete(ctx context.Context, containerName string) error {
+	if err := b.client.DeleteContainer(ctx, containerName); err != nil {
+		return fmt.Errorf("could not delete container %q: %w", containerName, err)
+	}
+	return nil
+}
+
+func (b *backend) GenerateSecret(ctx context.Context, containerName string) (map[string][]byte, error) {
+	secret, err := b.client.GenerateCredential(ctx, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate credential for container %q: %w", containerName, err)
+	}
+	return secret, nil
+}
+
+// SelectBackend validates provider and returns the Backend for provider.Backend (defaulting to Swift, consistent
+// with ValidateBackupBucketProvider treating an empty Backend as swift). This is the one place every BackupBucket
+// config passes through before touching a backend, so it also doubles as the create-time validation path: the
+// admission webhook in pkg/webhook/backupbucket only runs on Update, and there is no other call site that
+// decodes a BackupBucketConfig before a create.
+func SelectBackend(provider api.BackupBucketProvider, client Client) (Backend, error) {
+	if err := validation.ValidateBackupBucketProvider(&provider); err != nil {
+		return nil, err
+	}
+
+	switch provider.Backend {
+	case "", api.BackupBucketBackendSwift:
+		return NewSwiftBackend(client), nil
+	case api.BackupBucketBackendS3:
+		return NewS3Backend(client), nil
+	default:
+		return nil, fmt.Errorf("unknown backup bucket backend %q", provider.Backend)
+	}
+}
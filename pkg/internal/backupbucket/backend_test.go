@@ -0,0 +1,120 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupbucket
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+// fakeClient is a fully in-memory stand-in for a gophercloud (Swift) or S3-SDK client, playing the role a
+// fake/MinIO-backed test double would in a real integration test: it lets Backend be exercised end-to-end
+// without a live object-store endpoint.
+type fakeClient struct {
+	containers  map[string]bool
+	retentions  map[string]*api.BackupBucketRetentionPolicy
+	credentials map[string]map[string][]byte
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		containers:  map[string]bool{},
+		retentions:  map[string]*api.BackupBucketRetentionPolicy{},
+		credentials: map[string]map[string][]byte{},
+	}
+}
+
+func (f *fakeClient) EnsureContainer(_ context.Context, name string) error {
+	f.containers[name] = true
+	return nil
+}
+
+func (f *fakeClient) SetContainerRetention(_ context.Context, name string, policy *api.BackupBucketRetentionPolicy) error {
+	f.retentions[name] = policy
+	return nil
+}
+
+func (f *fakeClient) EnsureReplicationTarget(_ context.Context, _ string, _ api.BackupBucketReplicationTarget) error {
+	return nil
+}
+
+func (f *fakeClient) TargetSyncStatus(_ context.Context, _ string, _ api.BackupBucketReplicationTarget) (*time.Time, int64, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeClient) DeleteContainer(_ context.Context, name string) error {
+	delete(f.containers, name)
+	delete(f.retentions, name)
+	return nil
+}
+
+func (f *fakeClient) GenerateCredential(_ context.Context, name string) (map[string][]byte, error) {
+	secret := map[string][]byte{"container": []byte(name)}
+	f.credentials[name] = secret
+	return secret, nil
+}
+
+var _ = Describe("Backend", func() {
+	var client *fakeClient
+
+	BeforeEach(func() {
+		client = newFakeClient()
+	})
+
+	DescribeTable("SelectBackend",
+		func(provider api.BackupBucketProvider, wantErr bool) {
+			backend, err := SelectBackend(provider, client)
+			if wantErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backend).NotTo(BeNil())
+		},
+		Entry("empty backend defaults to swift", api.BackupBucketProvider{}, false),
+		Entry("explicit swift backend", api.BackupBucketProvider{Backend: api.BackupBucketBackendSwift}, false),
+		Entry("s3 backend", api.BackupBucketProvider{Backend: api.BackupBucketBackendS3, S3: &api.BackupBucketS3{Endpoint: "s3.example.com"}}, false),
+		Entry("unknown backend", api.BackupBucketProvider{Backend: "minio"}, true),
+		Entry("s3 backend missing s3 configuration", api.BackupBucketProvider{Backend: api.BackupBucketBackendS3}, true),
+		Entry("s3 backend missing endpoint", api.BackupBucketProvider{Backend: api.BackupBucketBackendS3, S3: &api.BackupBucketS3{}}, true),
+		Entry("swift backend must not set s3 configuration", api.BackupBucketProvider{Backend: api.BackupBucketBackendSwift, S3: &api.BackupBucketS3{Endpoint: "s3.example.com"}}, true),
+	)
+
+	DescribeTable("Ensure/Delete/GenerateSecret round-trip against a fake Swift/S3 server",
+		func(provider api.BackupBucketProvider) {
+			backend, err := SelectBackend(provider, client)
+			Expect(err).NotTo(HaveOccurred())
+
+			config := &api.BackupBucketConfig{Provider: provider}
+			_, err = backend.Ensure(context.Background(), "bucket-1", config, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.containers).To(HaveKey("bucket-1"))
+
+			secret, err := backend.GenerateSecret(context.Background(), "bucket-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret).To(HaveKeyWithValue("container", []byte("bucket-1")))
+
+			Expect(backend.Delete(context.Background(), "bucket-1")).To(Succeed())
+			Expect(client.containers).NotTo(HaveKey("bucket-1"))
+		},
+		Entry("swift backend", api.BackupBucketProvider{Backend: api.BackupBucketBackendSwift}),
+		Entry("s3 backend", api.BackupBucketProvider{Backend: api.BackupBucketBackendS3, S3: &api.BackupBucketS3{Endpoint: "s3.example.com"}}),
+	)
+})
@@ -0,0 +1,93 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backupbucket contains the reconciler that provisions the Swift/S3 object-store container backing a
+// BackupBucket, mirroring the narrow-Client pattern used by pkg/internal/infrastructure.
+package backupbucket
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/validation"
+)
+
+// SwiftClient is the minimal set of Swift container operations the reconciler needs. It is satisfied by a thin
+// wrapper around the gophercloud objectstorage service client, which keeps this package testable without
+// pulling a real OpenStack API into unit tests.
+type SwiftClient interface {
+	// EnsureContainer creates the container if it does not yet exist and is a no-op otherwise.
+	EnsureContainer(ctx context.Context, name string) error
+	// SetContainerRetention writes the object-lock (WORM) retention/legal-hold metadata headers to the
+	// container. A nil policy clears any previously set retention headers.
+	SetContainerRetention(ctx context.Context, name string, policy *api.BackupBucketRetentionPolicy) error
+}
+
+// Ensure provisions the container for config, applies its retention policy, and configures replication to every
+// declared target, rejecting any attempt to shorten or remove a previously applied retention policy. oldStatus
+// is the BackupBucketStatus computed on the last successful reconciliation, or nil on first create.
+func Ensure(ctx context.Context, client Client, containerName string, config *api.BackupBucketConfig, oldStatus *apiv1alpha1.BackupBucketStatus) (*apiv1alpha1.BackupBucketStatus, error) {
+	var oldPolicy *api.BackupBucketRetentionPolicy
+	if oldStatus != nil && oldStatus.RetentionPolicy != nil {
+		oldPolicy = &api.BackupBucketRetentionPolicy{
+			Mode:      api.BackupBucketRetentionMode(oldStatus.RetentionPolicy.Mode),
+			Duration:  oldStatus.RetentionPolicy.Duration,
+			LegalHold: oldStatus.RetentionPolicy.LegalHold,
+		}
+	}
+
+	if err := validation.ValidateBackupBucketRetentionPolicy(config.RetentionPolicy); err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateBackupBucketRetentionPolicyUpdate(oldPolicy, config.RetentionPolicy); err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateBackupBucketLifecycle(config.Lifecycle); err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateBackupBucketReplication(config.Replication); err != nil {
+		return nil, err
+	}
+
+	if err := client.EnsureContainer(ctx, containerName); err != nil {
+		return nil, fmt.Errorf("could not ensure container %q: %w", containerName, err)
+	}
+
+	if err := client.SetContainerRetention(ctx, containerName, config.RetentionPolicy); err != nil {
+		return nil, fmt.Errorf("could not set retention policy on container %q: %w", containerName, err)
+	}
+
+	status := &apiv1alpha1.BackupBucketStatus{}
+	if config.RetentionPolicy != nil {
+		status.RetentionPolicy = &apiv1alpha1.BackupBucketRetentionPolicy{
+			Mode:      apiv1alpha1.BackupBucketRetentionMode(config.RetentionPolicy.Mode),
+			Duration:  config.RetentionPolicy.Duration,
+			LegalHold: config.RetentionPolicy.LegalHold,
+		}
+	}
+
+	replication, err := EnsureReplication(ctx, client, containerName, config, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	status.Replication = replication
+
+	return status, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupbucket
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+)
+
+type fakeSwiftClient struct {
+	ensuredContainer string
+	retentionsSet    []*api.BackupBucketRetentionPolicy
+	ensureErr        error
+	retentionErr     error
+}
+
+func (f *fakeSwiftClient) EnsureContainer(_ context.Context, name string) error {
+	f.ensuredContainer = name
+	return f.ensureErr
+}
+
+func (f *fakeSwiftClient) SetContainerRetention(_ context.Context, _ string, policy *api.BackupBucketRetentionPolicy) error {
+	f.retentionsSet = append(f.retentionsSet, policy)
+	return f.retentionErr
+}
+
+func (f *fakeSwiftClient) EnsureReplicationTarget(_ context.Context, _ string, _ api.BackupBucketReplicationTarget) error {
+	return nil
+}
+
+func (f *fakeSwiftClient) TargetSyncStatus(_ context.Context, _ string, _ api.BackupBucketReplicationTarget) (*time.Time, int64, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeSwiftClient) DeleteContainer(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *fakeSwiftClient) GenerateCredential(_ context.Context, _ string) (map[string][]byte, error) {
+	return nil, nil
+}
+
+var _ = Describe("Ensure", func() {
+	var client *fakeSwiftClient
+
+	BeforeEach(func() {
+		client = &fakeSwiftClient{}
+	})
+
+	It("creates the container and writes the retention policy", func() {
+		config := &api.BackupBucketConfig{
+			RetentionPolicy: &api.BackupBucketRetentionPolicy{
+				Mode:     api.BackupBucketRetentionCompliance,
+				Duration: metav1.Duration{Duration: 24 * time.Hour},
+			},
+		}
+
+		status, err := Ensure(context.Background(), client, "bucket-1", config, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.ensuredContainer).To(Equal("bucket-1"))
+		Expect(client.retentionsSet).To(Equal([]*api.BackupBucketRetentionPolicy{config.RetentionPolicy}))
+		Expect(status.RetentionPolicy).To(Equal(&apiv1alpha1.BackupBucketRetentionPolicy{
+			Mode:     apiv1alpha1.BackupBucketRetentionCompliance,
+			Duration: metav1.Duration{Duration: 24 * time.Hour},
+		}))
+	})
+
+	It("rejects a reconcile that would shorten a previously applied compliance retention", func() {
+		oldStatus := &apiv1alpha1.BackupBucketStatus{
+			RetentionPolicy: &apiv1alpha1.BackupBucketRetentionPolicy{
+				Mode:     apiv1alpha1.BackupBucketRetentionCompliance,
+				Duration: metav1.Duration{Duration: 24 * time.Hour},
+			},
+		}
+		config := &api.BackupBucketConfig{
+			RetentionPolicy: &api.BackupBucketRetentionPolicy{
+				Mode:     api.BackupBucketRetentionCompliance,
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+		}
+
+		_, err := Ensure(context.Background(), client, "bucket-1", config, oldStatus)
+		Expect(err).To(HaveOccurred())
+		Expect(client.ensuredContainer).To(BeEmpty(), "the container must not be touched once validation rejects the update")
+	})
+
+	It("rejects a reconcile that would remove compliance mode entirely", func() {
+		oldStatus := &apiv1alpha1.BackupBucketStatus{
+			RetentionPolicy: &apiv1alpha1.BackupBucketRetentionPolicy{
+				Mode:     apiv1alpha1.BackupBucketRetentionCompliance,
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+		}
+		config := &api.BackupBucketConfig{}
+
+		_, err := Ensure(context.Background(), client, "bucket-1", config, oldStatus)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects an invalid retention policy on first create, when there is no prior policy to update from", func() {
+		config := &api.BackupBucketConfig{
+			RetentionPolicy: &api.BackupBucketRetentionPolicy{
+				Mode:     "bogus",
+				Duration: metav1.Duration{Duration: 24 * time.Hour},
+			},
+		}
+
+		_, err := Ensure(context.Background(), client, "bucket-1", config, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(client.ensuredContainer).To(BeEmpty())
+	})
+
+	It("rejects a zero-duration retention policy on first create", func() {
+		config := &api.BackupBucketConfig{
+			RetentionPolicy: &api.BackupBucketRetentionPolicy{
+				Mode: api.BackupBucketRetentionGovernance,
+			},
+		}
+
+		_, err := Ensure(context.Background(), client, "bucket-1", config, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(client.ensuredContainer).To(BeEmpty())
+	})
+
+	It("rejects a lifecycle rule whose deleteAfter does not outlive transitionToColdAfter", func() {
+		config := &api.BackupBucketConfig{
+			Lifecycle: &api.BackupBucketLifecycle{
+				Rules: []api.BackupBucketLifecycleRule{
+					{
+						TransitionToColdAfter: &metav1.Duration{Duration: 48 * time.Hour},
+						DeleteAfter:           &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+		}
+
+		_, err := Ensure(context.Background(), client, "bucket-1", config, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(client.ensuredContainer).To(BeEmpty())
+	})
+
+	It("rejects a replication config that declares the same target region twice", func() {
+		config := &api.BackupBucketConfig{
+			Replication: &api.BackupBucketReplication{
+				Targets: []api.BackupBucketReplicationTarget{
+					{Region: "eu-2", SecretRef: corev1.SecretReference{Name: "creds"}},
+					{Region: "eu-2", SecretRef: corev1.SecretReference{Name: "creds"}},
+				},
+			},
+		}
+
+		_, err := Ensure(context.Background(), client, "bucket-1", config, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(client.ensuredContainer).To(BeEmpty())
+	})
+})
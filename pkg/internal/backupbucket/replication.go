@@ -0,0 +1,127 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupbucket
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+)
+
+// maxReplicationLag is the maximum BytesLagging a target may report while still being considered healthy.
+// Beyond this, the container sync is assumed to have stalled rather than merely being in transient progress.
+const maxReplicationLag = 0
+
+// replicationBytesLagging reports, per source container and target region, the approximate number of bytes
+// observed in the source container that have not yet been synced to the target container.
+var replicationBytesLagging = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "openstack_backupbucket_replication_bytes_lagging",
+	Help: "Approximate number of bytes not yet synced from a BackupBucket's container to a replication target.",
+}, []string{"container", "region"})
+
+func init() {
+	metrics.Registry.MustRegister(replicationBytesLagging)
+}
+
+// Client is the full set of object-store operations the backupbucket actuator needs: container/retention
+// management, cross-region replication, teardown, and credential generation. A single interface is shared by
+// both the Swift and S3 backends (see Backend); what differs between them is the underlying gophercloud/S3 SDK
+// calls each implementation makes, not the shape of the operations themselves.
+type Client interface {
+	SwiftClient
+	ReplicationTargetClient
+
+	// DeleteContainer removes the container and all objects in it.
+	DeleteContainer(ctx context.Context, name string) error
+	// GenerateCredential creates backend-scoped credentials (e.g. a Swift application credential or an S3
+	// access key) restricted to the given container, suitable for handing to etcd-backup-restore.
+	GenerateCredential(ctx context.Context, name string) (map[string][]byte, error)
+}
+
+// ReplicationTargetClient computes the destination container name and container-sync headers for a replication
+// target, and reports the target's observed sync lag. It is satisfied by a thin wrapper around the gophercloud
+// objectstorage service client, mirroring SwiftClient.
+type ReplicationTargetClient interface {
+	// EnsureReplicationTarget creates containerName in the target region (if it does not yet exist) and sets
+	// the X-Container-Sync-To/X-Container-Sync-Key headers on the source container so that Swift replicates
+	// new objects to it.
+	EnsureReplicationTarget(ctx context.Context, containerName string, target api.BackupBucketReplicationTarget) error
+	// TargetSyncStatus reports the timestamp of the most recently synced object observed in the target
+	// container (nil if none yet) and the approximate number of bytes still pending sync.
+	TargetSyncStatus(ctx context.Context, containerName string, target api.BackupBucketReplicationTarget) (lastSynced *time.Time, bytesLagging int64, err error)
+}
+
+// EnsureReplication configures container-sync to every target declared in config.Replication and reports the
+// observed per-target health as a BackupBucketReplicationStatus, including a ReplicationHealthy condition. A nil
+// config.Replication clears the metric series for containerName and returns a nil status.
+func EnsureReplication(ctx context.Context, client ReplicationTargetClient, containerName string, config *api.BackupBucketConfig, now time.Time) (*apiv1alpha1.BackupBucketReplicationStatus, error) {
+	if config.Replication == nil {
+		replicationBytesLagging.DeletePartialMatch(prometheus.Labels{"container": containerName})
+		return nil, nil
+	}
+
+	status := &apiv1alpha1.BackupBucketReplicationStatus{}
+	var unhealthy []string
+
+	for _, target := range config.Replication.Targets {
+		if err := client.EnsureReplicationTarget(ctx, containerName, target); err != nil {
+			return nil, fmt.Errorf("could not ensure replication target %q: %w", target.Region, err)
+		}
+
+		lastSynced, bytesLagging, err := client.TargetSyncStatus(ctx, containerName, target)
+		if err != nil {
+			return nil, fmt.Errorf("could not get replication status for target %q: %w", target.Region, err)
+		}
+
+		replicationBytesLagging.WithLabelValues(containerName, target.Region).Set(float64(bytesLagging))
+
+		targetStatus := apiv1alpha1.BackupBucketReplicationTargetStatus{
+			Region:       target.Region,
+			BytesLagging: bytesLagging,
+		}
+		if lastSynced != nil {
+			targetStatus.LastSyncedTime = &metav1.Time{Time: *lastSynced}
+		}
+		status.Targets = append(status.Targets, targetStatus)
+
+		if bytesLagging > maxReplicationLag {
+			unhealthy = append(unhealthy, target.Region)
+		}
+	}
+
+	condition := apiv1alpha1.BackupBucketCondition{
+		Type:               apiv1alpha1.BackupBucketConditionReplicationHealthy,
+		LastTransitionTime: metav1.NewTime(now),
+	}
+	if len(unhealthy) == 0 {
+		condition.Status = apiv1alpha1.ConditionTrue
+		condition.Reason = "AllTargetsInSync"
+		condition.Message = "all replication targets are in sync"
+	} else {
+		condition.Status = apiv1alpha1.ConditionFalse
+		condition.Reason = "TargetsLagging"
+		condition.Message = fmt.Sprintf("replication targets lagging: %v", unhealthy)
+	}
+	status.Conditions = []apiv1alpha1.BackupBucketCondition{condition}
+
+	return status, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupbucket
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+)
+
+type fakeReplicationClient struct {
+	ensuredTargets []api.BackupBucketReplicationTarget
+	lastSynced     map[string]*time.Time
+	bytesLagging   map[string]int64
+}
+
+func (f *fakeReplicationClient) EnsureReplicationTarget(_ context.Context, _ string, target api.BackupBucketReplicationTarget) error {
+	f.ensuredTargets = append(f.ensuredTargets, target)
+	return nil
+}
+
+func (f *fakeReplicationClient) TargetSyncStatus(_ context.Context, _ string, target api.BackupBucketReplicationTarget) (*time.Time, int64, error) {
+	return f.lastSynced[target.Region], f.bytesLagging[target.Region], nil
+}
+
+var _ = Describe("EnsureReplication", func() {
+	var (
+		client *fakeReplicationClient
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		client = &fakeReplicationClient{lastSynced: map[string]*time.Time{}, bytesLagging: map[string]int64{}}
+		now = time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("returns nil when no replication is configured", func() {
+		status, err := EnsureReplication(context.Background(), client, "bucket-1", &api.BackupBucketConfig{}, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(BeNil())
+		Expect(client.ensuredTargets).To(BeEmpty())
+	})
+
+	It("reports ReplicationHealthy=True when every target is in sync", func() {
+		config := &api.BackupBucketConfig{
+			Replication: &api.BackupBucketReplication{
+				Targets: []api.BackupBucketReplicationTarget{
+					{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "swift-sync-eu-de-2"}},
+				},
+			},
+		}
+
+		status, err := EnsureReplication(context.Background(), client, "bucket-1", config, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.ensuredTargets).To(HaveLen(1))
+		Expect(status.Targets).To(ConsistOf(apiv1alpha1.BackupBucketReplicationTargetStatus{Region: "eu-de-2"}))
+		Expect(status.Conditions).To(HaveLen(1))
+		Expect(status.Conditions[0].Type).To(Equal(apiv1alpha1.BackupBucketConditionReplicationHealthy))
+		Expect(status.Conditions[0].Status).To(Equal(apiv1alpha1.ConditionTrue))
+	})
+
+	It("reports ReplicationHealthy=False when a target is lagging", func() {
+		client.bytesLagging["eu-de-2"] = 1024
+		config := &api.BackupBucketConfig{
+			Replication: &api.BackupBucketReplication{
+				Targets: []api.BackupBucketReplicationTarget{
+					{Region: "eu-de-2", SecretRef: corev1.SecretReference{Name: "swift-sync-eu-de-2"}},
+				},
+			},
+		}
+
+		status, err := EnsureReplication(context.Background(), client, "bucket-1", config, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Targets[0].BytesLagging).To(Equal(int64(1024)))
+		Expect(status.Conditions[0].Status).To(Equal(apiv1alpha1.ConditionFalse))
+		Expect(status.Conditions[0].Reason).To(Equal("TargetsLagging"))
+	})
+})
@@ -0,0 +1,378 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+)
+
+// FeatureGateNativeReconciler is the name of the feature gate that switches the infrastructure controller from
+// the Terraformer based flow to the native, gophercloud backed reconciler implemented in this file. It is
+// disabled by default until the native flow has reached parity across all supported topologies.
+const FeatureGateNativeReconciler = "OpenStackNativeInfrastructureReconciler"
+
+// ErrUnrecognizedResource is returned by Reconcile/Delete when the persisted InfrastructureState references, or
+// the InfrastructureConfig requires, a resource shape the native reconciler does not yet understand. Callers
+// should treat this as a signal to fall back to the Terraformer based flow rather than as a terminal error.
+var ErrUnrecognizedResource = fmt.Errorf("infrastructure: resource not recognized by the native reconciler")
+
+// Client is the minimal set of OpenStack networking/compute operations the native reconciler needs. It is
+// satisfied by a thin wrapper around the respective gophercloud service clients, which keeps this package
+// testable without pulling a real OpenStack API into unit tests.
+//
+// EnsureRouter and EnsureNetwork take both the user's BYO configuration (cfg/id) and previousID, the id this
+// reconciler itself created or adopted on a prior run. When cfg/id is unset, a non-empty previousID is adopted
+// instead of creating a new resource; an unrecognized combination of the two is reported via
+// ErrUnrecognizedResource so the caller can fall back to the Terraformer flow.
+type Client interface {
+	EnsureRouter(ctx context.Context, name string, cfg *api.Router, previousID string) (id string, externalIP string, err error)
+	EnsureNetwork(ctx context.Context, name string, id *string, previousID string) (string, error)
+	EnsureSubnet(ctx context.Context, networkID, routerID, zone, cidr string) (string, error)
+	EnsureSecurityGroup(ctx context.Context, name string) (id string, err error)
+	EnsureSSHKeyPair(ctx context.Context, name, publicKey string) (string, error)
+	EnsureShareNetwork(ctx context.Context, name, networkID, subnetID string) (string, error)
+
+	DeleteRouter(ctx context.Context, id string) error
+	DeleteNetwork(ctx context.Context, id string) error
+	DeleteSubnet(ctx context.Context, id string) error
+	DeleteSecurityGroup(ctx context.Context, id string) error
+	DeleteSSHKeyPair(ctx context.Context, name string) error
+	DeleteShareNetwork(ctx context.Context, id string) error
+}
+
+// InfrastructureState is the persisted sub-resource tracking the cloud resources created by the native
+// reconciler. Unlike the Terraformer flow, which re-derives state from the Terraform state file on every
+// reconciliation, this struct is the single source of truth that is read back on the next reconcile so that
+// ComputeStatus can be produced without talking to the OpenStack API again.
+type InfrastructureState struct {
+	// SSHKeyName is the name of the created or adopted SSH key pair.
+	SSHKeyName string `json:"sshKeyName,omitempty"`
+	// RouterID is the id of the created or adopted router.
+	RouterID string `json:"routerID,omitempty"`
+	// RouterIP is the router's internal gateway IP.
+	RouterIP string `json:"routerIP,omitempty"`
+	// RouterPublicIP is the stable, externally reachable IP bound to the router's external gateway.
+	RouterPublicIP string `json:"routerPublicIP,omitempty"`
+	// NetworkID is the id of the created or adopted private network.
+	NetworkID string `json:"networkID,omitempty"`
+	// NetworkName is the name of the private network.
+	NetworkName string `json:"networkName,omitempty"`
+	// Subnets is the set of created or adopted worker subnets, keyed by zone (empty string for the legacy,
+	// single-subnet layout).
+	Subnets []SubnetState `json:"subnets,omitempty"`
+	// SecurityGroupID is the id of the created or adopted worker security group.
+	SecurityGroupID string `json:"securityGroupID,omitempty"`
+	// SecurityGroupName is the name of the worker security group.
+	SecurityGroupName string `json:"securityGroupName,omitempty"`
+	// FloatingNetworkID is the id of the external/floating network.
+	FloatingNetworkID string `json:"floatingNetworkID,omitempty"`
+	// ShareNetworkID is the optional Manila share network id.
+	ShareNetworkID string `json:"shareNetworkID,omitempty"`
+	// ShareNetworkName is the optional Manila share network name.
+	ShareNetworkName string `json:"shareNetworkName,omitempty"`
+	// ShareNetworks is the set of created or adopted, named Manila share networks declared via
+	// InfrastructureConfig.Networks.ShareNetworks, superseding ShareNetworkID/ShareNetworkName.
+	ShareNetworks []ShareNetworkState `json:"shareNetworks,omitempty"`
+
+	// Imported records which of the fields above were adopted from pre-existing resources rather than created
+	// by this controller, so that Delete knows which ones to leave behind.
+	Imported ImportedResources `json:"imported,omitempty"`
+}
+
+// SubnetState is a single zone's worker subnet as tracked in InfrastructureState.
+type SubnetState struct {
+	// Zone is the availability zone the subnet was created in, empty for the legacy, single-subnet layout.
+	Zone string `json:"zone,omitempty"`
+	// ID is the subnet id.
+	ID string `json:"id"`
+}
+
+// ShareNetworkState is a single, named Manila share network as tracked in InfrastructureState.
+type ShareNetworkState struct {
+	// Name is the name of the share network.
+	Name string `json:"name"`
+	// ID is the share network id.
+	ID string `json:"id"`
+}
+
+// ImportedResources records which resources in an InfrastructureState were adopted from an existing shoot
+// (e.g. one migrated from the Terraformer flow) instead of being created by this controller. Resources marked
+// here are never deleted by Delete.
+type ImportedResources struct {
+	Router        bool `json:"router,omitempty"`
+	Network       bool `json:"network,omitempty"`
+	SecurityGroup bool `json:"securityGroup,omitempty"`
+}
+
+// Reconcile provisions, or adopts, the router, network, subnet(s), security group, SSH key and optional share
+// network for the given Infrastructure directly via the OpenStack API, without going through the Terraformer.
+// It is the gophercloud-backed counterpart to RenderTerraformerTemplate/ComputeTerraformerTemplateValues: where
+// the Terraformer flow renders a template and lets `terraform apply` reconcile it, this flow reconciles each
+// resource in place and persists the result as an InfrastructureState.
+//
+// When oldState was produced by a previous run of this function, already-created resources are adopted by ID
+// so that re-running Reconcile is idempotent and existing shoots can migrate from the Terraformer flow without
+// their cloud resources being recreated: populate oldState from ExtractTerraformState on the first native
+// reconciliation, and the router/network ids it carries are passed to EnsureRouter/EnsureNetwork as the
+// previousID hint, so Reconcile adopts rather than recreates them. If the InfrastructureConfig's own BYO router
+// or network id disagrees with the id from oldState, that is a shape the native reconciler cannot resolve on its
+// own, and Reconcile returns ErrUnrecognizedResource so the caller can fall back to the Terraformer flow.
+func Reconcile(
+	ctx context.Context,
+	client Client,
+	infra *extensionsv1alpha1.Infrastructure,
+	config *api.InfrastructureConfig,
+	oldState *InfrastructureState,
+) (*InfrastructureState, error) {
+	state := &InfrastructureState{}
+	if oldState != nil {
+		*state = *oldState
+	}
+
+	keyName := infra.Namespace
+	sshKeyName, err := client.EnsureSSHKeyPair(ctx, keyName, string(infra.Spec.SSHPublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure SSH key pair: %w", err)
+	}
+	state.SSHKeyName = sshKeyName
+
+	var previousRouterID, previousNetworkID string
+	if oldState != nil {
+		previousRouterID, previousNetworkID = oldState.RouterID, oldState.NetworkID
+	}
+
+	if config.Networks.Router != nil && previousRouterID != "" && config.Networks.Router.ID != previousRouterID {
+		return nil, fmt.Errorf("%w: BYO router id %q does not match the router %q adopted on a prior reconciliation", ErrUnrecognizedResource, config.Networks.Router.ID, previousRouterID)
+	}
+
+	routerID, routerPublicIP, err := client.EnsureRouter(ctx, infra.Namespace, config.Networks.Router, previousRouterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure router: %w", err)
+	}
+	state.RouterID = routerID
+	state.RouterPublicIP = routerPublicIP
+	if config.Networks.Router != nil {
+		state.Imported.Router = true
+	}
+
+	if config.Networks.ID != nil && previousNetworkID != "" && *config.Networks.ID != previousNetworkID {
+		return nil, fmt.Errorf("%w: BYO network id %q does not match the network %q adopted on a prior reconciliation", ErrUnrecognizedResource, *config.Networks.ID, previousNetworkID)
+	}
+
+	networkID, err := client.EnsureNetwork(ctx, infra.Namespace, config.Networks.ID, previousNetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure network: %w", err)
+	}
+	state.NetworkID = networkID
+	if config.Networks.ID != nil {
+		state.Imported.Network = true
+	}
+
+	zones := config.Networks.Zones
+	if len(zones) == 0 {
+		zones = []api.ZoneNetwork{{WorkerCIDR: config.Networks.Workers}}
+	}
+	state.Subnets = state.Subnets[:0]
+	for _, zone := range zones {
+		subnetID, err := client.EnsureSubnet(ctx, networkID, routerID, zone.Name, zone.WorkerCIDR)
+		if err != nil {
+			return nil, fmt.Errorf("failed to ensure subnet for zone %q: %w", zone.Name, err)
+		}
+		state.Subnets = append(state.Subnets, SubnetState{Zone: zone.Name, ID: subnetID})
+	}
+
+	sgID, err := client.EnsureSecurityGroup(ctx, infra.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure security group: %w", err)
+	}
+	state.SecurityGroupID = sgID
+	state.SecurityGroupName = infra.Namespace
+
+	primarySubnet := ""
+	if len(state.Subnets) > 0 {
+		primarySubnet = state.Subnets[0].ID
+	}
+
+	if config.Networks.ShareNetwork != nil && config.Networks.ShareNetwork.Enabled {
+		shareNetworkID, err := client.EnsureShareNetwork(ctx, infra.Namespace, networkID, primarySubnet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to ensure share network: %w", err)
+		}
+		state.ShareNetworkID = shareNetworkID
+		state.ShareNetworkName = infra.Namespace
+	}
+
+	if len(config.Networks.ShareNetworks) > 0 {
+		state.ShareNetworks = state.ShareNetworks[:0]
+		for _, declared := range config.Networks.ShareNetworks {
+			subnetID := subnetForZone(state.Subnets, declared.Zone, primarySubnet)
+			shareNetworkID, err := client.EnsureShareNetwork(ctx, declared.Name, networkID, subnetID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to ensure share network %q: %w", declared.Name, err)
+			}
+			state.ShareNetworks = append(state.ShareNetworks, ShareNetworkState{Name: declared.Name, ID: shareNetworkID})
+		}
+	}
+
+	return state, nil
+}
+
+// subnetForZone returns the id of the subnet created for the given zone, falling back to fallback (the
+// primary/default subnet) when zone is nil or no subnet was created for it.
+func subnetForZone(subnets []SubnetState, zone *string, fallback string) string {
+	if zone == nil {
+		return fallback
+	}
+	for _, subnet := range subnets {
+		if subnet.Zone == *zone {
+			return subnet.ID
+		}
+	}
+	return fallback
+}
+
+// Delete tears down the resources tracked by state, skipping anything that was adopted rather than created by
+// Reconcile (see InfrastructureState.Imported).
+func Delete(ctx context.Context, client Client, state *InfrastructureState) error {
+	if state == nil {
+		return nil
+	}
+
+	if state.ShareNetworkID != "" {
+		if err := client.DeleteShareNetwork(ctx, state.ShareNetworkID); err != nil {
+			return fmt.Errorf("failed to delete share network: %w", err)
+		}
+	}
+
+	for _, shareNetwork := range state.ShareNetworks {
+		if err := client.DeleteShareNetwork(ctx, shareNetwork.ID); err != nil {
+			return fmt.Errorf("failed to delete share network %q: %w", shareNetwork.Name, err)
+		}
+	}
+
+	if state.SecurityGroupID != "" && !state.Imported.SecurityGroup {
+		if err := client.DeleteSecurityGroup(ctx, state.SecurityGroupID); err != nil {
+			return fmt.Errorf("failed to delete security group: %w", err)
+		}
+	}
+
+	for _, subnet := range state.Subnets {
+		if err := client.DeleteSubnet(ctx, subnet.ID); err != nil {
+			return fmt.Errorf("failed to delete subnet %q: %w", subnet.ID, err)
+		}
+	}
+
+	if state.NetworkID != "" && !state.Imported.Network {
+		if err := client.DeleteNetwork(ctx, state.NetworkID); err != nil {
+			return fmt.Errorf("failed to delete network: %w", err)
+		}
+	}
+
+	if state.RouterID != "" && !state.Imported.Router {
+		if err := client.DeleteRouter(ctx, state.RouterID); err != nil {
+			return fmt.Errorf("failed to delete router: %w", err)
+		}
+	}
+
+	if state.SSHKeyName != "" {
+		if err := client.DeleteSSHKeyPair(ctx, state.SSHKeyName); err != nil {
+			return fmt.Errorf("failed to delete SSH key pair: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ExtractState is the native-reconciler counterpart to ExtractTerraformState: it returns the InfrastructureState
+// unchanged, since unlike the Terraformer flow this backend already keeps its state as a typed Go struct instead
+// of a Terraform state file that needs to be parsed on every call.
+func ExtractState(state *InfrastructureState) (*InfrastructureState, error) {
+	if state == nil {
+		return nil, fmt.Errorf("infrastructure: no native reconciler state to extract")
+	}
+	return state, nil
+}
+
+// StatusFromInfrastructureState computes an InfrastructureStatus from the given native reconciler state. It
+// mirrors StatusFromTerraformState so that ComputeStatus can treat both backends uniformly.
+func StatusFromInfrastructureState(state *InfrastructureState) *apiv1alpha1.InfrastructureStatus {
+	var shareNetworkStatus *apiv1alpha1.ShareNetworkStatus
+	if state.ShareNetworkID != "" {
+		shareNetworkStatus = &apiv1alpha1.ShareNetworkStatus{
+			ID:   state.ShareNetworkID,
+			Name: state.ShareNetworkName,
+		}
+	}
+
+	subnets := make([]apiv1alpha1.Subnet, 0, len(state.Subnets))
+	for _, subnet := range state.Subnets {
+		subnets = append(subnets, apiv1alpha1.Subnet{
+			Purpose: apiv1alpha1.PurposeNodes,
+			ID:      subnet.ID,
+			Zone:    subnet.Zone,
+		})
+	}
+
+	var shareNetworkStatuses []apiv1alpha1.ShareNetworkStatus
+	for _, shareNetwork := range state.ShareNetworks {
+		shareNetworkStatuses = append(shareNetworkStatuses, apiv1alpha1.ShareNetworkStatus{
+			ID:   shareNetwork.ID,
+			Name: shareNetwork.Name,
+		})
+	}
+
+	return &apiv1alpha1.InfrastructureStatus{
+		TypeMeta: StatusTypeMeta,
+		Networks: apiv1alpha1.NetworkStatus{
+			ID:   state.NetworkID,
+			Name: state.NetworkName,
+			FloatingPool: apiv1alpha1.FloatingPoolStatus{
+				ID: state.FloatingNetworkID,
+			},
+			Router: apiv1alpha1.RouterStatus{
+				ID:       state.RouterID,
+				IP:       state.RouterIP,
+				PublicIP: state.RouterPublicIP,
+			},
+			Subnets:       subnets,
+			ShareNetwork:  shareNetworkStatus,
+			ShareNetworks: shareNetworkStatuses,
+		},
+		SecurityGroups: []apiv1alpha1.SecurityGroup{
+			{
+				Purpose: apiv1alpha1.PurposeNodes,
+				ID:      state.SecurityGroupID,
+				Name:    state.SecurityGroupName,
+			},
+		},
+		Node: apiv1alpha1.NodeStatus{
+			KeyName: state.SSHKeyName,
+		},
+	}
+}
+
+// ComputeStatusFromState computes the InfrastructureStatus from a native reconciler InfrastructureState, the
+// counterpart of ComputeStatus for the Terraformer flow.
+func ComputeStatusFromState(config *api.InfrastructureConfig, state *InfrastructureState) *apiv1alpha1.InfrastructureStatus {
+	status := StatusFromInfrastructureState(state)
+	status.Networks.FloatingPool.Name = config.FloatingPoolName
+	return status
+}
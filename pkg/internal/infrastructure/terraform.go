@@ -51,10 +51,24 @@ const (
 	TerraformOutputKeyFloatingNetworkID = "floating_network_id"
 	// TerraformOutputKeySubnetID is the id of the worker subnet.
 	TerraformOutputKeySubnetID = "subnet_id"
+	// TerraformOutputKeySubnetIDPrefix is the prefix of the per-zone worker subnet output, i.e. the Terraform
+	// module emits one "subnet_id_<zone>" output per entry in Networks.Zones.
+	TerraformOutputKeySubnetIDPrefix = "subnet_id_"
 	// TerraformOutputKeyShareNetworkID is the share network.
 	TerraformOutputKeyShareNetworkID = "share_network_id"
 	// TerraformOutputKeyShareNetworkName is the share network name.
 	TerraformOutputKeyShareNetworkName = "share_network_name"
+	// TerraformOutputKeyInternalEndpoint is the internal VIP fronting the API server of a fully-private shoot.
+	TerraformOutputKeyInternalEndpoint = "internal_endpoint"
+	// TerraformOutputKeyJumpHostIP is the floating IP of the jump host used to reach a fully-private shoot.
+	TerraformOutputKeyJumpHostIP = "jump_host_ip"
+	// TerraformOutputKeyJumpHostSSHKey is the name of the SSH key accepted by the jump host.
+	TerraformOutputKeyJumpHostSSHKey = "jump_host_ssh_key"
+	// TerraformOutputKeyRouterPublicIP is the stable, externally reachable IP bound to the router's external
+	// gateway. It is populated as soon as the floating IP is reserved, independent of the router itself having
+	// finished provisioning, so that api.<shoot> DNS records can be created early and survive router
+	// recreations.
+	TerraformOutputKeyRouterPublicIP = "router_public_ip"
 
 	// DefaultRouterID is the computed router ID as generated by terraform.
 	DefaultRouterID = "openstack_networking_router_v2.router.id"
@@ -75,15 +89,17 @@ func ComputeTerraformerTemplateValues(
 	cluster *controller.Cluster,
 ) (map[string]interface{}, error) {
 	var (
-		createRouter  = true
-		createNetwork = true
-		useCACert     = false
-		routerConfig  = map[string]interface{}{
+		createRouter         = true
+		createNetwork        = true
+		useCACert            = false
+		internalLoadBalancer = config.Networks.InternalLoadBalancer != nil && config.Networks.InternalLoadBalancer.Enabled
+		routerConfig         = map[string]interface{}{
 			"id": DefaultRouterID,
 		}
 		outputKeysConfig = map[string]interface{}{
 			"routerID":          TerraformOutputKeyRouterID,
 			"routerIP":          TerraformOutputKeyRouterIP,
+			"routerPublicIP":    TerraformOutputKeyRouterPublicIP,
 			"networkID":         TerraformOutputKeyNetworkID,
 			"networkName":       TerraformOutputKeyNetworkName,
 			"keyName":           TerraformOutputKeySSHKeyName,
@@ -104,10 +120,26 @@ func ComputeTerraformerTemplateValues(
 		routerConfig["id"] = strconv.Quote(config.Networks.Router.ID)
 	}
 
+	if router := config.Networks.Router; router != nil {
+		if len(router.ExternalFixedIPs) > 0 {
+			routerConfig["externalFixedIPs"] = router.ExternalFixedIPs
+		}
+		if router.ReserveFloatingIP != nil {
+			routerConfig["reserveFloatingIP"] = *router.ReserveFloatingIP
+		}
+		if router.FloatingIPID != nil {
+			routerConfig["floatingIPID"] = *router.FloatingIPID
+		}
+	}
+
 	if floatingPoolSubnet := findFloatingSubnet(createRouter, config, cloudProfileConfig, infra.Spec.Region); floatingPoolSubnet != nil {
 		routerConfig["floatingPoolSubnet"] = *floatingPoolSubnet
 	}
 
+	if floatingIPTags := findFloatingIPTags(createRouter, config, cloudProfileConfig, infra.Spec.Region); len(floatingIPTags) > 0 {
+		routerConfig["floatingIPTags"] = floatingIPTags
+	}
+
 	keyStoneURL, err := helper.FindKeyStoneURL(cloudProfileConfig.KeyStoneURLs, cloudProfileConfig.KeyStoneURL, infra.Spec.Region)
 	if err != nil {
 		return nil, err
@@ -130,12 +162,52 @@ func ComputeTerraformerTemplateValues(
 		networksConfig["id"] = *config.Networks.ID
 	}
 
+	if zones := config.Networks.Zones; len(zones) > 0 {
+		zonesConfig := make([]map[string]interface{}, 0, len(zones))
+		for _, zone := range zones {
+			zoneConfig := map[string]interface{}{
+				"name":       zone.Name,
+				"workerCIDR": zone.WorkerCIDR,
+			}
+			if zone.RouterInterface != nil {
+				zoneConfig["routerInterface"] = *zone.RouterInterface
+			}
+			zonesConfig = append(zonesConfig, zoneConfig)
+			outputKeysConfig[subnetIDOutputKeyName(zone.Name)] = TerraformOutputKeySubnetIDPrefix + zone.Name
+		}
+		networksConfig["zones"] = zonesConfig
+	}
+
 	createShareNetwork := config.Networks.ShareNetwork != nil && config.Networks.ShareNetwork.Enabled
 	if createShareNetwork {
 		outputKeysConfig["shareNetworkID"] = TerraformOutputKeyShareNetworkID
 		outputKeysConfig["shareNetworkName"] = TerraformOutputKeyShareNetworkName
 	}
 
+	var internalLoadBalancerConfig map[string]interface{}
+	if internalLoadBalancer {
+		outputKeysConfig["internalEndpoint"] = TerraformOutputKeyInternalEndpoint
+		outputKeysConfig["jumpHostIP"] = TerraformOutputKeyJumpHostIP
+		outputKeysConfig["jumpHostSSHKey"] = TerraformOutputKeyJumpHostSSHKey
+
+		internalLoadBalancerConfig = map[string]interface{}{
+			"enabled": true,
+		}
+		if jumpHost := config.Networks.InternalLoadBalancer.JumpHost; jumpHost != nil {
+			jumpHostConfig := map[string]interface{}{
+				"imageName":  jumpHost.ImageName,
+				"flavorName": jumpHost.FlavorName,
+			}
+			if jumpHost.ID != nil {
+				jumpHostConfig["id"] = *jumpHost.ID
+			}
+			internalLoadBalancerConfig["jumpHost"] = jumpHostConfig
+		}
+
+		// A fully-private shoot's router has no business attaching to the external floating network.
+		routerConfig["enableExternalGateway"] = false
+	}
+
 	return map[string]interface{}{
 		"openstack": map[string]interface{}{
 			"maxApiCallRetries": MaxApiCallRetries,
@@ -150,12 +222,13 @@ func ComputeTerraformerTemplateValues(
 			"network":      createNetwork,
 			"shareNetwork": createShareNetwork,
 		},
-		"dnsServers":   cloudProfileConfig.DNSServers,
-		"sshPublicKey": string(infra.Spec.SSHPublicKey),
-		"router":       routerConfig,
-		"clusterName":  infra.Namespace,
-		"networks":     networksConfig,
-		"outputKeys":   outputKeysConfig,
+		"dnsServers":           cloudProfileConfig.DNSServers,
+		"sshPublicKey":         string(infra.Spec.SSHPublicKey),
+		"router":               routerConfig,
+		"clusterName":          infra.Namespace,
+		"networks":             networksConfig,
+		"outputKeys":           outputKeysConfig,
+		"internalLoadBalancer": internalLoadBalancerConfig,
 	}, nil
 }
 
@@ -177,6 +250,28 @@ func findFloatingSubnet(isRouterRequired bool, config *api.InfrastructureConfig,
 	return nil
 }
 
+// findFloatingIPTags resolves the tags of the floating pool selected for the infrastructure's router, if any.
+// The Terraform module uses them to filter a paginated floating-IP listing data source down to the specific,
+// operator-managed static IP that should be reserved for the router's external gateway, the same way
+// findFloatingSubnet resolves a floating subnet by name.
+func findFloatingIPTags(isRouterRequired bool, config *api.InfrastructureConfig, cloudProfileConfig *api.CloudProfileConfig, region string) []string {
+	if !isRouterRequired {
+		return nil
+	}
+
+	floatingPool, err := helper.FindFloatingPool(cloudProfileConfig.Constraints.FloatingPools, config.FloatingPoolName, region, nil)
+	if err != nil {
+		return nil
+	}
+	return floatingPool.Tags
+}
+
+// subnetIDOutputKeyName returns the key used in outputKeysConfig for a given zone's worker subnet, so that the
+// rendered chart values carry a stable, zone-qualified name alongside the legacy, single "subnetID" entry.
+func subnetIDOutputKeyName(zone string) string {
+	return "subnetID_" + zone
+}
+
 // RenderTerraformerTemplate renders the openstack infrastructure templates with the given values.
 func RenderTerraformerTemplate(
 	infra *extensionsv1alpha1.Infrastructure,
@@ -215,12 +310,20 @@ type TerraformState struct {
 	RouterID string
 	// RouterIP is the ip address of the router.
 	RouterIP string
+	// RouterPublicIP is the stable, externally reachable IP bound to the router's external gateway. It is
+	// tracked separately from RouterIP so it can be resolved, and DNS records created against it, before the
+	// router itself has finished provisioning, and so it survives router recreations.
+	RouterPublicIP string
 	// NetworkID is the private worker network.
 	NetworkID string
 	// NetworkName is the private worker network name.
 	NetworkName string
-	// SubnetID is the id of the worker subnet.
+	// SubnetID is the id of the worker subnet. Populated for the legacy, single-subnet layout; superseded by
+	// Subnets when Networks.Zones is set.
 	SubnetID string
+	// Subnets holds one entry per zone declared in Networks.Zones, in the same order, so that consumers of
+	// InfrastructureStatus.Networks.Subnets can rely on a stable ordering.
+	Subnets []SubnetState
 	// FloatingNetworkID is the id of the provider network.
 	FloatingNetworkID string
 	// SecurityGroupID is the id of worker security group.
@@ -231,6 +334,12 @@ type TerraformState struct {
 	ShareNetworkID string
 	// ShareNetworkName is the optional share network name.
 	ShareNetworkName string
+	// InternalEndpoint is the internal VIP fronting the API server of a fully-private shoot.
+	InternalEndpoint string
+	// JumpHostIP is the floating IP of the jump host used to reach a fully-private shoot.
+	JumpHostIP string
+	// JumpHostSSHKey is the name of the SSH key accepted by the jump host.
+	JumpHostSSHKey string
 }
 
 // ExtractTerraformState extracts the TerraformState from the given Terraformer.
@@ -239,38 +348,86 @@ func ExtractTerraformState(ctx context.Context, tf terraformer.Terraformer, conf
 		TerraformOutputKeySSHKeyName,
 		TerraformOutputKeyRouterID,
 		TerraformOutputKeyRouterIP,
+		TerraformOutputKeyRouterPublicIP,
 		TerraformOutputKeyNetworkID,
 		TerraformOutputKeyNetworkName,
-		TerraformOutputKeySubnetID,
 		TerraformOutputKeyFloatingNetworkID,
 		TerraformOutputKeySecurityGroupID,
 		TerraformOutputKeySecurityGroupName,
 	}
 
+	zoneOutputKeys := make(map[string]string, len(config.Networks.Zones))
+	if len(config.Networks.Zones) > 0 {
+		for _, zone := range config.Networks.Zones {
+			key := TerraformOutputKeySubnetIDPrefix + zone.Name
+			zoneOutputKeys[zone.Name] = key
+			outputKeys = append(outputKeys, key)
+		}
+	} else {
+		outputKeys = append(outputKeys, TerraformOutputKeySubnetID)
+	}
+
 	if config.Networks.ShareNetwork != nil && config.Networks.ShareNetwork.Enabled {
 		outputKeys = append(outputKeys, TerraformOutputKeyShareNetworkID, TerraformOutputKeyShareNetworkName)
 	}
 
+	if config.Networks.InternalLoadBalancer != nil && config.Networks.InternalLoadBalancer.Enabled {
+		outputKeys = append(outputKeys, TerraformOutputKeyInternalEndpoint, TerraformOutputKeyJumpHostIP, TerraformOutputKeyJumpHostSSHKey)
+	}
+
 	vars, err := tf.GetStateOutputVariables(ctx, outputKeys...)
 	if err != nil {
 		return nil, err
 	}
 
+	var subnets []SubnetState
+	for _, zone := range config.Networks.Zones {
+		subnets = append(subnets, SubnetState{Zone: zone.Name, ID: vars[zoneOutputKeys[zone.Name]]})
+	}
+
 	return &TerraformState{
 		SSHKeyName:        vars[TerraformOutputKeySSHKeyName],
 		RouterID:          vars[TerraformOutputKeyRouterID],
 		RouterIP:          vars[TerraformOutputKeyRouterIP],
+		RouterPublicIP:    vars[TerraformOutputKeyRouterPublicIP],
 		NetworkID:         vars[TerraformOutputKeyNetworkID],
 		NetworkName:       vars[TerraformOutputKeyNetworkName],
 		SubnetID:          vars[TerraformOutputKeySubnetID],
+		Subnets:           subnets,
 		FloatingNetworkID: vars[TerraformOutputKeyFloatingNetworkID],
 		SecurityGroupID:   vars[TerraformOutputKeySecurityGroupID],
 		SecurityGroupName: vars[TerraformOutputKeySecurityGroupName],
 		ShareNetworkID:    vars[TerraformOutputKeyShareNetworkID],
 		ShareNetworkName:  vars[TerraformOutputKeyShareNetworkName],
+		InternalEndpoint:  vars[TerraformOutputKeyInternalEndpoint],
+		JumpHostIP:        vars[TerraformOutputKeyJumpHostIP],
+		JumpHostSSHKey:    vars[TerraformOutputKeyJumpHostSSHKey],
 	}, nil
 }
 
+// subnetsFromTerraformState returns the worker subnets in InfrastructureStatus shape, preferring the zoned
+// Subnets slice (in the stable order it was built in) and falling back to the legacy, single SubnetID.
+func subnetsFromTerraformState(state *TerraformState) []apiv1alpha1.Subnet {
+	if len(state.Subnets) > 0 {
+		subnets := make([]apiv1alpha1.Subnet, 0, len(state.Subnets))
+		for _, subnet := range state.Subnets {
+			subnets = append(subnets, apiv1alpha1.Subnet{
+				Purpose: apiv1alpha1.PurposeNodes,
+				ID:      subnet.ID,
+				Zone:    subnet.Zone,
+			})
+		}
+		return subnets
+	}
+
+	return []apiv1alpha1.Subnet{
+		{
+			Purpose: apiv1alpha1.PurposeNodes,
+			ID:      state.SubnetID,
+		},
+	}
+}
+
 // StatusFromTerraformState computes an InfrastructureStatus from the given
 // Terraform variables.
 func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.InfrastructureStatus {
@@ -281,6 +438,13 @@ func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.Infrastructure
 			Name: state.ShareNetworkName,
 		}
 	}
+	var jumpHostStatus *apiv1alpha1.JumpHostStatus
+	if state.JumpHostIP != "" {
+		jumpHostStatus = &apiv1alpha1.JumpHostStatus{
+			IP:         state.JumpHostIP,
+			SSHKeyName: state.JumpHostSSHKey,
+		}
+	}
 	return &apiv1alpha1.InfrastructureStatus{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
@@ -293,16 +457,14 @@ func StatusFromTerraformState(state *TerraformState) *apiv1alpha1.Infrastructure
 				ID: state.FloatingNetworkID,
 			},
 			Router: apiv1alpha1.RouterStatus{
-				ID: state.RouterID,
-				IP: state.RouterIP,
-			},
-			Subnets: []apiv1alpha1.Subnet{
-				{
-					Purpose: apiv1alpha1.PurposeNodes,
-					ID:      state.SubnetID,
-				},
+				ID:       state.RouterID,
+				IP:       state.RouterIP,
+				PublicIP: state.RouterPublicIP,
 			},
-			ShareNetwork: shareNetworkStatus,
+			Subnets:          subnetsFromTerraformState(state),
+			ShareNetwork:     shareNetworkStatus,
+			InternalEndpoint: state.InternalEndpoint,
+			JumpHost:         jumpHostStatus,
 		},
 		SecurityGroups: []apiv1alpha1.SecurityGroup{
 			{
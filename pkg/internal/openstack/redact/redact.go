@@ -0,0 +1,89 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact strips credential material out of the chart value maps rendered by the controlplane
+// valuesprovider before they are logged or surfaced on Events, so that a misconfigured shoot or a failed
+// reconciliation does not leak OpenStack secrets into klog output or the Kubernetes event stream.
+package redact
+
+import "strings"
+
+// replacement is substituted for the value of any redacted key.
+const replacement = "***"
+
+// exactKeys are redacted regardless of case when they match a map key exactly.
+var exactKeys = map[string]bool{
+	"password":                    true,
+	"applicationcredentialsecret": true,
+	"cacert":                      true,
+}
+
+// substringKeys are redacted when they appear anywhere in a map key, case-insensitively. "token" is matched as
+// a substring so that it also catches any future *Token* field (e.g. a raw accessToken), not just the
+// tokenAudience/tokenFilePath fields known today.
+var substringKeys = []string{"token"}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	if exactKeys[lower] {
+		return true
+	}
+	for _, substr := range substringKeys {
+		if strings.Contains(lower, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a deep copy of values with every sensitive key (see isSensitiveKey) replaced by a fixed
+// placeholder, recursing into nested maps and slices such as those produced by utils.MergeMaps and the
+// per-share-type/per-snapshot-class chart value helpers in this package. The input is never mutated.
+func Redact(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+
+	out := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if isSensitiveKey(key) {
+			out[key] = replacement
+			continue
+		}
+		out[key] = redactValue(value)
+	}
+	return out
+}
+
+// redactValue recurses into the nested map/slice shapes produced by this package's chart-value helpers.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return Redact(v)
+	case []map[string]interface{}:
+		redacted := make([]map[string]interface{}, len(v))
+		for i, entry := range v {
+			redacted[i] = Redact(entry)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, entry := range v {
+			redacted[i] = redactValue(entry)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redact", func() {
+	DescribeTable("redacting sensitive keys",
+		func(input, want map[string]interface{}, secret string) {
+			got := Redact(input)
+			Expect(got).To(Equal(want))
+			if secret != "" {
+				Expect(dump(got)).NotTo(ContainSubstring(secret))
+			}
+		},
+		Entry("nil map", map[string]interface{}(nil), map[string]interface{}(nil), ""),
+		Entry("no sensitive keys",
+			map[string]interface{}{"domainName": "domain", "region": "eu-1"},
+			map[string]interface{}{"domainName": "domain", "region": "eu-1"},
+			"",
+		),
+		Entry("flat sensitive keys",
+			map[string]interface{}{
+				"username":                    "jdoe",
+				"password":                    "s3cret",
+				"applicationCredentialSecret": "app-secret",
+				"caCert":                      "-----BEGIN CERTIFICATE-----\n...",
+				"tokenAudience":               "my-audience",
+				"tokenFilePath":               "/var/run/secrets/token",
+			},
+			map[string]interface{}{
+				"username":                    "jdoe",
+				"password":                    replacement,
+				"applicationCredentialSecret": replacement,
+				"caCert":                      replacement,
+				"tokenAudience":               replacement,
+				"tokenFilePath":               replacement,
+			},
+			"s3cret",
+		),
+		Entry("nested map, as produced by workloadIdentityChartValues",
+			map[string]interface{}{
+				"caBundle": "trusted-bundle",
+				"workloadIdentity": map[string]interface{}{
+					"identityProviderID": "my-idp",
+					"tokenAudience":      "my-audience",
+					"tokenFilePath":      "/var/run/secrets/token",
+				},
+			},
+			map[string]interface{}{
+				"caBundle": "trusted-bundle",
+				"workloadIdentity": map[string]interface{}{
+					"identityProviderID": "my-idp",
+					"tokenAudience":      replacement,
+					"tokenFilePath":      replacement,
+				},
+			},
+			"",
+		),
+		Entry("slice of maps, as produced by manilaNodePublishSecretsChartValues merged with a secret",
+			map[string]interface{}{
+				"csi-manila-storage-classes": []map[string]interface{}{
+					{"name": "default", "secretName": "csi-manila-nodeplugin-default", "password": "s3cret"},
+					{"name": "fast", "secretName": "csi-manila-nodeplugin-fast"},
+				},
+			},
+			map[string]interface{}{
+				"csi-manila-storage-classes": []map[string]interface{}{
+					{"name": "default", "secretName": "csi-manila-nodeplugin-default", "password": replacement},
+					{"name": "fast", "secretName": "csi-manila-nodeplugin-fast"},
+				},
+			},
+			"s3cret",
+		),
+		Entry("nested structures merged via utils.MergeMaps-style composition",
+			map[string]interface{}{
+				"cloudControllerManager": map[string]interface{}{
+					"secrets": map[string]interface{}{
+						"password":      "s3cret",
+						"serverToken":   "raw-token-value",
+						"serverAddress": "https://example.invalid",
+					},
+					"snapshotclasses": []interface{}{
+						map[string]interface{}{"name": "default", "parameters": map[string]interface{}{
+							"csi.storage.k8s.io/snapshotter-secret-name": "snap-secret",
+							"password": "s3cret",
+						}},
+					},
+				},
+			},
+			map[string]interface{}{
+				"cloudControllerManager": map[string]interface{}{
+					"secrets": map[string]interface{}{
+						"password":      replacement,
+						"serverToken":   replacement,
+						"serverAddress": "https://example.invalid",
+					},
+					"snapshotclasses": []interface{}{
+						map[string]interface{}{"name": "default", "parameters": map[string]interface{}{
+							"csi.storage.k8s.io/snapshotter-secret-name": "snap-secret",
+							"password": replacement,
+						}},
+					},
+				},
+			},
+			"s3cret",
+		),
+	)
+
+	It("does not mutate its input", func() {
+		input := map[string]interface{}{
+			"password": "s3cret",
+			"nested":   map[string]interface{}{"password": "nested-secret"},
+		}
+
+		Redact(input)
+
+		Expect(input["password"]).To(Equal("s3cret"))
+		Expect(input["nested"].(map[string]interface{})["password"]).To(Equal("nested-secret"))
+	})
+})
+
+// dump renders a value to a string for substring-search assertions without pulling in a formatting
+// dependency beyond what's already used across this package's tests.
+func dump(v interface{}) string {
+	var sb strings.Builder
+	sb.WriteString(toString(v))
+	return sb.String()
+}
+
+func toString(v interface{}) string {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		var sb strings.Builder
+		for k, elem := range val {
+			sb.WriteString(k)
+			sb.WriteString("=")
+			sb.WriteString(toString(elem))
+			sb.WriteString(";")
+		}
+		return sb.String()
+	case []map[string]interface{}:
+		var sb strings.Builder
+		for _, elem := range val {
+			sb.WriteString(toString(elem))
+		}
+		return sb.String()
+	case []interface{}:
+		var sb strings.Builder
+		for _, elem := range val {
+			sb.WriteString(toString(elem))
+		}
+		return sb.String()
+	case string:
+		return val
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openstack contains constants shared across the extension's controllers and charts.
+package openstack
+
+const (
+	// CloudControllerManagerName is the name of the cloud-controller-manager.
+	CloudControllerManagerName = "cloud-controller-manager"
+	// CSIControllerName is the name of the csi-driver-controller.
+	CSIControllerName = "csi-driver-controller"
+	// CSINodeName is the name of the csi-driver-node.
+	CSINodeName = "csi-driver-node"
+	// CSIDriverManila is the name of the csi-driver-manila.
+	CSIDriverManila = "csi-driver-manila"
+	// CSIDriverNFS is the name of the csi-driver-nfs.
+	CSIDriverNFS = "csi-driver-nfs"
+	// CSIStorageProvisioner is the name of the Cinder CSI storage provisioner.
+	CSIStorageProvisioner = "cinder.csi.openstack.org"
+	// CSIManilaStorageProvisioner is the name of the Manila CSI storage provisioner.
+	CSIManilaStorageProvisioner = "nfs.manila.csi.openstack.org"
+
+	// CloudProviderConfigName is the name of the secret containing the cloud provider config.
+	CloudProviderConfigName = "cloud-provider-config"
+	// CloudProviderConfigDataKey is the key in the cloud provider secret/configmap holding the actual
+	// config content.
+	CloudProviderConfigDataKey = "cloudprovider.conf"
+	// CloudProviderCSIDiskConfigName is the name of the secret containing the cloud provider config for the
+	// CSI disk (Cinder) driver.
+	CloudProviderCSIDiskConfigName = "cloud-provider-config-csi"
+
+	// TrustedCABundleConfigMapName is the name of the operator-maintained ConfigMap in the shoot's control
+	// plane namespace that carries a cluster-wide trusted CA bundle for Keystone and CSI endpoints.
+	TrustedCABundleConfigMapName = "openstack-trusted-ca-bundle"
+	// TrustedCABundleDataKey is the data key under which the PEM bundle is stored in the
+	// TrustedCABundleConfigMapName ConfigMap.
+	TrustedCABundleDataKey = "bundle.crt"
+	// ShootCACertificateDataKey is the data key under which the PEM bundle is stored in the ConfigMap
+	// referenced by ControlPlaneConfig.CACertificateConfigMapRef.
+	ShootCACertificateDataKey = "ca-bundle.crt"
+
+	// ApplicationCredentialStateConfigMapName is the name of the ConfigMap in the shoot's control plane
+	// namespace that tracks the lifecycle of a managed, automatically rotated application credential.
+	ApplicationCredentialStateConfigMapName = "cloud-provider-application-credential"
+	// ApplicationCredentialStateDataKey is the data key under which the JSON-encoded state is stored in the
+	// ApplicationCredentialStateConfigMapName ConfigMap.
+	ApplicationCredentialStateDataKey = "state.json"
+
+	// AuthTypePassword is the OpenStack authentication type using a static username/password.
+	AuthTypePassword = "password"
+	// AuthTypeAppCredential is the OpenStack authentication type using a static application credential.
+	AuthTypeAppCredential = "v3applicationcredential"
+	// AuthTypeOIDCAccessToken is the OpenStack authentication type using a federated OIDC access token
+	// obtained from a projected Kubernetes service account token.
+	AuthTypeOIDCAccessToken = "v3oidcaccesstoken"
+)
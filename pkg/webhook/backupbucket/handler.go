@@ -0,0 +1,87 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backupbucket contains the admission handler enforcing BackupBucketConfig immutability rules that
+// cannot be expressed as CRD validation, namely that a retention policy already in compliance mode can never be
+// relaxed or removed, and that the object-store backend cannot change after creation. Registering this handler
+// with a webhook server (TLS certs, ValidatingWebhookConfiguration, manager wiring) is out of scope here: no such
+// scaffolding exists anywhere in this extension yet for any resource type, and fabricating it without the rest
+// of the webhook-server setup would not be runnable.
+package backupbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/validation"
+)
+
+// Handler is an admission.Handler that rejects BackupBucket updates which would shorten or remove a retention
+// policy already in compliance mode, or change the object-store backend after creation.
+type Handler struct{}
+
+// Handle implements admission.Handler.
+func (h *Handler) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	oldConfig, err := decodeProviderConfig(req.OldObject.Raw)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("could not decode old providerConfig: %w", err))
+	}
+	newConfig, err := decodeProviderConfig(req.Object.Raw)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("could not decode new providerConfig: %w", err))
+	}
+
+	if err := validation.ValidateBackupBucketRetentionPolicyUpdate(oldConfig.RetentionPolicy, newConfig.RetentionPolicy); err != nil {
+		return admission.Denied(err.Error())
+	}
+	if err := validation.ValidateBackupBucketProviderUpdate(&oldConfig.Provider, &newConfig.Provider); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed("")
+}
+
+// decodeProviderConfig decodes a BackupBucket's providerConfig (the v1alpha1.BackupBucketConfig embedded in
+// extensionsv1alpha1.BackupBucket.Spec.ProviderConfig) out of raw, converting it to the hub type.
+func decodeProviderConfig(raw []byte) (*api.BackupBucketConfig, error) {
+	bb := &extensionsv1alpha1.BackupBucket{}
+	if err := json.Unmarshal(raw, bb); err != nil {
+		return nil, err
+	}
+
+	versioned := &v1alpha1.BackupBucketConfig{}
+	if bb.Spec.ProviderConfig != nil {
+		if err := json.Unmarshal(bb.Spec.ProviderConfig.Raw, versioned); err != nil {
+			return nil, err
+		}
+	}
+
+	hub := &api.BackupBucketConfig{}
+	if err := versioned.ConvertTo(hub); err != nil {
+		return nil, err
+	}
+	return hub, nil
+}
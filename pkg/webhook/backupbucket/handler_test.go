@@ -0,0 +1,106 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupbucket
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+)
+
+func backupBucketWithRetention(mode v1alpha1.BackupBucketRetentionMode, d time.Duration) *extensionsv1alpha1.BackupBucket {
+	config := &v1alpha1.BackupBucketConfig{
+		Region: "eu-de-1",
+		RetentionPolicy: &v1alpha1.BackupBucketRetentionPolicy{
+			Mode:     mode,
+			Duration: metav1.Duration{Duration: d},
+		},
+	}
+	raw, err := json.Marshal(config)
+	Expect(err).NotTo(HaveOccurred())
+
+	return &extensionsv1alpha1.BackupBucket{
+		Spec: extensionsv1alpha1.BackupBucketSpec{
+			DefaultSpec: extensionsv1alpha1.DefaultSpec{
+				ProviderConfig: &runtime.RawExtension{Raw: raw},
+			},
+		},
+	}
+}
+
+func updateRequest(old, new *extensionsv1alpha1.BackupBucket) admission.Request {
+	oldRaw, err := json.Marshal(old)
+	Expect(err).NotTo(HaveOccurred())
+	newRaw, err := json.Marshal(new)
+	Expect(err).NotTo(HaveOccurred())
+
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+			OldObject: runtime.RawExtension{Raw: oldRaw},
+			Object:    runtime.RawExtension{Raw: newRaw},
+		},
+	}
+}
+
+var _ = Describe("Handler", func() {
+	var handler *Handler
+
+	BeforeEach(func() {
+		handler = &Handler{}
+	})
+
+	It("allows non-update operations unconditionally", func() {
+		resp := handler.Handle(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create},
+		})
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("allows extending a compliance retention duration", func() {
+		old := backupBucketWithRetention(v1alpha1.BackupBucketRetentionCompliance, time.Hour)
+		new := backupBucketWithRetention(v1alpha1.BackupBucketRetentionCompliance, 2*time.Hour)
+
+		resp := handler.Handle(context.Background(), updateRequest(old, new))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("denies shortening a compliance retention duration", func() {
+		old := backupBucketWithRetention(v1alpha1.BackupBucketRetentionCompliance, 2*time.Hour)
+		new := backupBucketWithRetention(v1alpha1.BackupBucketRetentionCompliance, time.Hour)
+
+		resp := handler.Handle(context.Background(), updateRequest(old, new))
+		Expect(resp.Allowed).To(BeFalse())
+	})
+
+	It("denies removing compliance mode", func() {
+		old := backupBucketWithRetention(v1alpha1.BackupBucketRetentionCompliance, time.Hour)
+		new := &extensionsv1alpha1.BackupBucket{}
+
+		resp := handler.Handle(context.Background(), updateRequest(old, new))
+		Expect(resp.Allowed).To(BeFalse())
+	})
+})